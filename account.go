@@ -0,0 +1,240 @@
+package gozaya
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Features of an account plan, as listed in AccountDefaults.Features
+const (
+	FeatureLinkPassword   = "link_password"
+	FeatureLinkExpiration = "link_expiration"
+	FeatureLinkStats      = "link_stats"
+	FeatureDomains        = "domains"
+	FeatureSpaces         = "spaces"
+)
+
+// AccountDefaults holds the defaults and plan restrictions of an account
+type AccountDefaults struct {
+	DefaultDomain  *int64 `json:"default_domain"`
+	DefaultSpace   *int64 `json:"default_space"`
+	MaxAliasLength int    `json:"max_alias_length"`
+	// Features lists the features allowed by the plan. A nil list means unrestricted.
+	Features []string `json:"features"`
+}
+
+// Allows reports whether the plan allows a feature
+func (d *AccountDefaults) Allows(feature string) bool {
+	return d.Features == nil || slices.Contains(d.Features, feature)
+}
+
+type accountDefaultsResponse struct {
+	Data *AccountDefaults `json:"data" required:"true"`
+}
+
+// GetAccountDefaults returns the defaults and plan restrictions of the account
+func (g *GoZaya) GetAccountDefaults(ctx context.Context, token string) (*AccountDefaults, error) {
+	var result accountDefaultsResponse
+
+	path, err := endpointPath("AccountEndpoint", g.Config.AccountEndpoint, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account defaults: %w", err)
+	}
+
+	ctx = withOperation(ctx, OperationGetAccountDefaults)
+	resp, err := g.get(ctx, token, path, nil, "failed to get account defaults")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.decodeResponse(resp, &result, "failed to parse account defaults response"); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// ErrPlanRestriction is matched by the errors returned when a link request is rejected
+// locally because of the restrictions of the account plan
+var ErrPlanRestriction = errors.New("request is not allowed by the account plan")
+
+// PlanRestrictionError is returned when a link request violates the account defaults
+type PlanRestrictionError struct {
+	Field  string
+	Reason string
+}
+
+// Error stringifies the PlanRestrictionError
+func (e *PlanRestrictionError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Reason)
+}
+
+// Unwrap allows matching the error with ErrPlanRestriction
+func (e *PlanRestrictionError) Unwrap() error {
+	return ErrPlanRestriction
+}
+
+// tokenCacheFailureTTL is how long a failure to fetch a value is cached, at most the TTL of
+// the cache, so that a failing endpoint is not called again by every request.
+const tokenCacheFailureTTL = 5 * time.Second
+
+// tokenCache caches a value fetched per token, keyed by a hash of the token. The failures are
+// cached briefly, and the concurrent fetches of a token share one call.
+type tokenCache[T any] struct {
+	ttl time.Duration
+	// clone copies the values returned by get, so that the cached ones are not shared, when set
+	clone func(T) T
+
+	mu       sync.Mutex
+	entries  map[string]tokenCacheEntry[T]
+	inFlight map[string]*tokenCacheFetch[T]
+}
+
+type tokenCacheEntry[T any] struct {
+	value     T
+	err       error
+	expiresAt time.Time
+}
+
+// tokenCacheFetch is a fetch in flight, shared by the concurrent calls of a token
+type tokenCacheFetch[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func newTokenCache[T any](ttl time.Duration) *tokenCache[T] {
+	return &tokenCache[T]{ttl: ttl, entries: make(map[string]tokenCacheEntry[T]), inFlight: make(map[string]*tokenCacheFetch[T])}
+}
+
+// get returns the cached value of the token of the call, or its cached failure, calling fetch
+// when it is missing or stale. fetch is called with the token of the call rather than the
+// resolved one, so that the token provider drops its token when the API rejects it.
+func (c *tokenCache[T]) get(ctx context.Context, g *GoZaya, token string, fetch func(ctx context.Context, token string) (T, error)) (T, error) {
+	var zero T
+	resolved, err := g.authorize(ctx, token)
+	if err != nil {
+		return zero, err
+	}
+	key := tokenCacheKey(resolved)
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && time.Now().Before(entry.expiresAt) {
+		c.mu.Unlock()
+		return c.copied(entry.value), entry.err
+	}
+	current, ok := c.inFlight[key]
+	if ok {
+		c.mu.Unlock()
+		select {
+		case <-current.done:
+			return c.copied(current.value), current.err
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+	current = &tokenCacheFetch[T]{done: make(chan struct{})}
+	c.inFlight[key] = current
+	c.mu.Unlock()
+
+	current.value, current.err = fetch(ctx, token)
+
+	c.mu.Lock()
+	delete(c.inFlight, key)
+	switch {
+	case current.err == nil:
+		c.entries[key] = tokenCacheEntry[T]{value: current.value, expiresAt: time.Now().Add(c.ttl)}
+	case ctx.Err() == nil:
+		// the failures caused by the cancellation of the call are not the endpoint's
+		c.entries[key] = tokenCacheEntry[T]{err: current.err, expiresAt: time.Now().Add(min(c.ttl, tokenCacheFailureTTL))}
+	}
+	c.mu.Unlock()
+	close(current.done)
+	return c.copied(current.value), current.err
+}
+
+// update replaces the cached value of the token of the call with change(value), when it is
+// cached, without fetching it.
+func (c *tokenCache[T]) update(ctx context.Context, g *GoZaya, token string, change func(T) T) {
+	resolved, err := g.authorize(ctx, token)
+	if err != nil {
+		return
+	}
+	key := tokenCacheKey(resolved)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok && entry.err == nil {
+		entry.value = change(c.copied(entry.value))
+		c.entries[key] = entry
+	}
+}
+
+// copied returns a copy of a value made with clone, the value itself without clone
+func (c *tokenCache[T]) copied(value T) T {
+	if c.clone == nil {
+		return value
+	}
+	return c.clone(value)
+}
+
+// tokenCacheKey returns the key of a resolved token in a tokenCache
+func tokenCacheKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// WithAccountDefaultsValidation validates the link create and update requests against the
+// account defaults before sending them, so that plan restrictions fail without a round trip.
+// The defaults are fetched once per token and cached for ttl. When they cannot be fetched,
+// the requests are sent unvalidated and the API remains the authority; the failure is cached
+// for up to 5 seconds before the defaults are fetched again.
+func WithAccountDefaultsValidation(ttl time.Duration) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.accountDefaults = newTokenCache[*AccountDefaults](ttl)
+	}
+}
+
+// checkAccountDefaults validates a link request against the cached account defaults.
+func (g *GoZaya) checkAccountDefaults(ctx context.Context, token string, link *GenerateLinkRequest) error {
+	if g.accountDefaults == nil || link == nil {
+		return nil
+	}
+	defaults, err := g.accountDefaults.get(ctx, g, token, g.GetAccountDefaults)
+	if err != nil || defaults == nil {
+		return nil
+	}
+	return validateLinkRequest(defaults, link)
+}
+
+// validateLinkRequest checks a link request against the account defaults.
+func validateLinkRequest(defaults *AccountDefaults, link *GenerateLinkRequest) error {
+	if defaults.MaxAliasLength > 0 && utf8.RuneCountInString(link.Alias) > defaults.MaxAliasLength {
+		return &PlanRestrictionError{Field: "alias", Reason: fmt.Sprintf("longer than %d characters", defaults.MaxAliasLength)}
+	}
+
+	restricted := []struct {
+		field   string
+		used    bool
+		feature string
+	}{
+		{"password", link.Password != "", FeatureLinkPassword},
+		{"expiration", link.ExpirationDate != "" || link.ExpirationTime != "" || link.ExpirationClicks != nil || link.ExpirationUrl != "", FeatureLinkExpiration},
+		{"privacy", link.Privacy != nil || link.Public != nil, FeatureLinkStats},
+		{"domain", link.Domain != nil, FeatureDomains},
+		{"space", link.Space != nil, FeatureSpaces},
+	}
+	for _, field := range restricted {
+		if field.used && !defaults.Allows(field.feature) {
+			return &PlanRestrictionError{Field: field.field, Reason: fmt.Sprintf("feature %q is not included in the plan", field.feature)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package gozaya
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// checkAliasesConcurrency is the number of aliases checked concurrently by CheckAliases.
+	checkAliasesConcurrency = 8
+	// aliasSearchLimit is the number of links containing an alias searched for it, so that a
+	// lookup costs at most 5 pages of links.
+	aliasSearchLimit = 5 * listAllPageSize
+)
+
+// ErrTooManyAliasMatches is returned when too many links contain an alias to search them all
+// for it, e.g. for a single letter
+var ErrTooManyAliasMatches = errors.New("too many links contain the alias")
+
+// AliasAvailability is the outcome of the check of one alias by CheckAliases
+type AliasAvailability struct {
+	Alias     string
+	Available bool
+	// Link is the link already using the alias, nil when the alias is free or forbidden
+	Link *Link
+	// Err is set when the alias is forbidden by the alias policy, or could not be checked
+	Err error
+}
+
+// CheckAliases checks whether the aliases are free on the domain with the given ID, e.g. to flag
+// all the conflicts of a bulk import before creating any link. A domainID of 0 or less checks
+// the aliases across all the domains. The aliases forbidden by the alias policy of the client
+// are reported unavailable with their policy error. The results are returned in input order.
+// The returned error is only set when ctx is done before all the aliases were checked.
+func (g *GoZaya) CheckAliases(ctx context.Context, token string, aliases []string, domainID int) ([]AliasAvailability, error) {
+	results := make([]AliasAvailability, len(aliases))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(checkAliasesConcurrency, len(aliases)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				results[index] = g.checkAliasAvailability(ctx, token, aliases[index], domainID)
+			}
+		}()
+	}
+
+	var err error
+	for index := range aliases {
+		select {
+		case indexes <- index:
+			continue
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		for ; index < len(aliases); index++ {
+			results[index] = AliasAvailability{Alias: aliases[index], Err: err}
+		}
+		break
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, err
+}
+
+// checkAliasAvailability looks for a link using an alias, ignoring case to be on the safe side.
+func (g *GoZaya) checkAliasAvailability(ctx context.Context, token string, alias string, domainID int) AliasAvailability {
+	result := AliasAvailability{Alias: alias}
+	if err := g.checkAlias(ctx, &GenerateLinkRequest{Alias: alias}); err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Link, result.Err = g.findLinkByAlias(ctx, token, alias, domainID, strings.EqualFold)
+	result.Available = result.Link == nil && result.Err == nil
+	return result
+}
+
+// GetLinkByAlias returns the link with the given alias on the domain with the given ID, or on
+// any domain when domainID is 0 or less. When no link uses the alias, the returned *APIError
+// matches ErrNotFound. With WithLinkCache, the links and the aliases without link are cached.
+// The search gives up with ErrTooManyAliasMatches when more than 500 links contain the alias.
+func (g *GoZaya) GetLinkByAlias(ctx context.Context, token string, alias string, domainID int) (link *Link, err error) {
+	if g.linkCache != nil {
+		link, err = g.getCachedLinkByAlias(ctx, token, alias, domainID)
+	} else {
+		link, err = g.findLinkByAlias(ctx, token, alias, domainID, exactAlias)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if link == nil {
+		return nil, &APIError{Code: http.StatusNotFound, Message: fmt.Sprintf("failed to get link by alias: no link with alias %q", alias)}
+	}
+	return link, nil
+}
+
+// findLinkByAlias searches the links by alias. The search of the API matches substrings, so
+// the results are filtered with match, up to aliasSearchLimit results. It returns nil when no
+// link matches.
+func (g *GoZaya) findLinkByAlias(ctx context.Context, token string, alias string, domainID int, match func(a, b string) bool) (*Link, error) {
+	params := GetLinksParams{
+		Search:   StringP(alias),
+		SearchBy: StringP("alias"),
+		PerPage:  IntP(listAllPageSize),
+	}
+	if domainID > 0 {
+		params.Domain = IntP(domainID)
+	}
+	searched := 0
+	for link, err := range g.IterateLinks(ctx, token, params) {
+		if err != nil {
+			return nil, err
+		}
+		if match(link.Alias, alias) {
+			return link, nil
+		}
+		if searched++; searched == aliasSearchLimit {
+			return nil, fmt.Errorf("failed to find link by alias %q: %w", alias, ErrTooManyAliasMatches)
+		}
+	}
+	return nil, nil
+}
+
+// exactAlias matches the aliases equal to the searched one
+func exactAlias(a, b string) bool {
+	return a == b
+}
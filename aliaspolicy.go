@@ -0,0 +1,213 @@
+package gozaya
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrAliasForbidden is matched by the errors returned when an alias policy rejects an alias
+var ErrAliasForbidden = errors.New("alias is forbidden by policy")
+
+// AliasPolicy is consulted before a link with an alias is created or updated.
+// CheckAlias returns an error to reject the alias.
+type AliasPolicy interface {
+	CheckAlias(ctx context.Context, alias string) error
+}
+
+// AliasPolicyFunc adapts a function to the AliasPolicy interface
+type AliasPolicyFunc func(ctx context.Context, alias string) error
+
+// CheckAlias calls f(ctx, alias)
+func (f AliasPolicyFunc) CheckAlias(ctx context.Context, alias string) error {
+	return f(ctx, alias)
+}
+
+// AliasPolicyError is returned when an alias matches a forbidden pattern
+type AliasPolicyError struct {
+	Alias   string
+	Pattern string
+}
+
+// Error stringifies the AliasPolicyError
+func (e *AliasPolicyError) Error() string {
+	return fmt.Sprintf("alias %q is forbidden by policy (matches %q)", e.Alias, e.Pattern)
+}
+
+// Unwrap allows matching the error with ErrAliasForbidden
+func (e *AliasPolicyError) Unwrap() error {
+	return ErrAliasForbidden
+}
+
+// WithAliasPolicy makes the client consult the policy before creating or updating a link with an alias.
+func WithAliasPolicy(policy AliasPolicy) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.aliasPolicy = policy
+	}
+}
+
+// checkAlias applies the alias policy of the client, if any.
+func (g *GoZaya) checkAlias(ctx context.Context, link *GenerateLinkRequest) error {
+	if g.aliasPolicy == nil || link == nil || link.Alias == "" {
+		return nil
+	}
+	return g.aliasPolicy.CheckAlias(ctx, link.Alias)
+}
+
+// aliasPatterns is a set of case-insensitive, path.Match style patterns
+type aliasPatterns []string
+
+func (p aliasPatterns) check(alias string) error {
+	lower := strings.ToLower(alias)
+	for _, pattern := range p {
+		if ok, _ := path.Match(pattern, lower); ok {
+			return &AliasPolicyError{Alias: alias, Pattern: pattern}
+		}
+	}
+	return nil
+}
+
+func newAliasPatterns(patterns []string) aliasPatterns {
+	result := make(aliasPatterns, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		result = append(result, pattern)
+	}
+	return result
+}
+
+// StaticAliasPolicy returns a policy forbidding the aliases matching any of the patterns.
+// Patterns are case-insensitive and use the path.Match syntax, e.g. "acme*".
+func StaticAliasPolicy(patterns ...string) AliasPolicy {
+	forbidden := newAliasPatterns(patterns)
+	return AliasPolicyFunc(func(_ context.Context, alias string) error {
+		return forbidden.check(alias)
+	})
+}
+
+// RemoteAliasPolicy forbids the aliases listed by a remote policy service.
+// The list is either a JSON array of patterns or a text document with one pattern per line,
+// lines starting with "#" being comments. Patterns use the same syntax as StaticAliasPolicy.
+type RemoteAliasPolicy struct {
+	// URL is the location of the forbidden aliases list.
+	URL string
+	// HTTPClient is used to fetch the list. http.DefaultClient is used when nil.
+	HTTPClient *http.Client
+	// FailClosed rejects every alias while the list has never been loaded successfully.
+	FailClosed bool
+
+	mu        sync.RWMutex
+	forbidden aliasPatterns
+	loaded    bool
+}
+
+// NewRemoteAliasPolicy returns a policy loading its forbidden aliases from url.
+// Call Refresh or Run to load the list.
+func NewRemoteAliasPolicy(url string) *RemoteAliasPolicy {
+	return &RemoteAliasPolicy{URL: url}
+}
+
+// CheckAlias rejects the alias if it matches the last loaded list
+func (p *RemoteAliasPolicy) CheckAlias(_ context.Context, alias string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if !p.loaded && p.FailClosed {
+		return &AliasPolicyError{Alias: alias, Pattern: "*"}
+	}
+	return p.forbidden.check(alias)
+}
+
+// Refresh fetches the list from the policy service. The previous list is kept on failure.
+func (p *RemoteAliasPolicy) Refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build alias policy request: %w", err)
+	}
+
+	client := p.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch alias policy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch alias policy: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read alias policy: %w", err)
+	}
+
+	patterns, err := parseAliasList(body)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.forbidden = patterns
+	p.loaded = true
+	p.mu.Unlock()
+
+	return nil
+}
+
+// Run refreshes the list every interval until ctx is done, then returns ctx.Err().
+// Refresh errors are passed to onError when it is not nil. It fails right away when interval
+// is not positive.
+func (p *RemoteAliasPolicy) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("failed to run alias policy: interval %s is not positive", interval)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Refresh(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func parseAliasList(body []byte) (aliasPatterns, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var patterns []string
+		if err := json.Unmarshal(body, &patterns); err != nil {
+			return nil, fmt.Errorf("failed to parse alias policy: %w", err)
+		}
+		return newAliasPatterns(patterns), nil
+	}
+
+	var patterns []string
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		patterns = append(patterns, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse alias policy: %w", err)
+	}
+	return newAliasPatterns(patterns), nil
+}
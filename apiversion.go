@@ -0,0 +1,109 @@
+package gozaya
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Endpoints holds the paths of the endpoints of the API, relative to the base path. The
+// endpoints taking an ID may place it with the {id} placeholder, e.g. "api/v1/links/{id}";
+// the ID is appended to the others.
+type Endpoints struct {
+	CreateLinkEndpoint string
+	GetLinkEndpoint    string
+	ListLinksEndpoint  string
+	UpdateLinkEndpoint string
+	RemoveLinkEndpoint string
+
+	ListDomainsEndpoint string
+	ListSpacesEndpoint  string
+	ListPixelsEndpoint  string
+
+	PublicStatsEndpoint string
+	StatsEndpoint       string
+	AccountEndpoint     string
+	PlanEndpoint        string
+
+	LoginEndpoint        string
+	RefreshTokenEndpoint string
+}
+
+// APIVersion lays out the endpoints of a version of the API, so that versions of other shapes
+// can be supported side by side with the ones laid out by StandardAPIVersion.
+type APIVersion interface {
+	// Name returns the name of the version, e.g. "v1"
+	Name() string
+	// Endpoints returns the endpoints of the version
+	Endpoints() Endpoints
+}
+
+// APIv1 is the version 1 of the API, used by default
+var APIv1 APIVersion = StandardAPIVersion("v1")
+
+// StandardAPIVersion is a version of the API with the endpoints of version 1 under the
+// "api/<name>" prefix, e.g. "api/v2/links" for "v2"
+type StandardAPIVersion string
+
+// Name returns the name of the version
+func (v StandardAPIVersion) Name() string {
+	return string(v)
+}
+
+// Endpoints returns the endpoints of the version
+func (v StandardAPIVersion) Endpoints() Endpoints {
+	name := string(v)
+	return Endpoints{
+		CreateLinkEndpoint: makeURL("api", name, "links"),
+		GetLinkEndpoint:    makeURL("api", name, "links"),
+		ListLinksEndpoint:  makeURL("api", name, "links"),
+		UpdateLinkEndpoint: makeURL("api", name, "links"),
+		RemoveLinkEndpoint: makeURL("api", name, "links"),
+
+		ListDomainsEndpoint: makeURL("api", name, "domains"),
+		ListSpacesEndpoint:  makeURL("api", name, "spaces"),
+		ListPixelsEndpoint:  makeURL("api", name, "pixels"),
+
+		PublicStatsEndpoint: "stats",
+		StatsEndpoint:       makeURL("api", name, "stats"),
+		AccountEndpoint:     makeURL("api", name, "account"),
+		PlanEndpoint:        makeURL("api", name, "account", "plan"),
+
+		LoginEndpoint:        makeURL("api", name, "login"),
+		RefreshTokenEndpoint: makeURL("api", name, "refresh"),
+	}
+}
+
+// WithAPIVersion calls the version of the API with the given name, e.g. "v2", laid out like
+// version 1 under the "api/<name>" prefix. It replaces the endpoints set before it. It fails,
+// and then every call, when name is not a single path segment.
+func WithAPIVersion(name string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if name == "" || strings.ContainsAny(name, "/?#{}") || strings.TrimSpace(name) != name {
+			g.optionFailed("WithAPIVersion", fmt.Errorf("invalid API version %q: want a single path segment, e.g. \"v2\"", name))
+			return
+		}
+		g.setAPIVersion(StandardAPIVersion(name))
+	}
+}
+
+// WithAPIVersionLayout calls the version of the API laid out by version, e.g. a version whose
+// endpoints do not follow the layout of version 1. It replaces the endpoints set before it.
+func WithAPIVersionLayout(version APIVersion) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if version == nil {
+			version = APIv1
+		}
+		g.setAPIVersion(version)
+	}
+}
+
+// APIVersion returns the name of the version of the API called by the client, e.g. "v1"
+func (g *GoZaya) APIVersion() string {
+	return g.apiVersion.Name()
+}
+
+// setAPIVersion sets the version of the API and its endpoints.
+func (g *GoZaya) setAPIVersion(version APIVersion) {
+	g.apiVersion = version
+	g.Config = version.Endpoints()
+}
@@ -0,0 +1,111 @@
+package gozaya
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// Option configures a client
+type Option = func(*GoZaya)
+
+// tuning holds the settings that can be changed on a live client with Apply.
+// It is replaced as a whole, never modified in place.
+type tuning struct {
+	rateLimiter    RateLimiter
+	retry          *retryPolicy
+	rateLimitRetry *rateLimitRetryPolicy
+	responseHook   func(ctx context.Context, meta *ResponseMeta)
+	sizeBudget     *SizeBudget
+	errorHook      func(ctx context.Context, err *APIError)
+	rateLimitHook  func(ctx context.Context, limit RateLimit)
+	callHooks      []callHook
+	// attemptHook is called with the outcome of every attempt sent, see Router
+	attemptHook    func(ctx context.Context, meta *ResponseMeta, err error)
+	logger         *slog.Logger
+	logLevels      *LogLevels
+	debug          *debugWriter
+	events         Events
+	redactor       Redactor
+	hedgeThreshold time.Duration
+	timeout        time.Duration
+	concurrency    concurrencyLimit
+
+	retryMaxElapsed time.Duration
+	retryBudget     *RetryBudget
+}
+
+// newTuning returns a holder of the tuning of a client, starting from a copy of t.
+func newTuning(t *tuning) *atomic.Pointer[tuning] {
+	holder := &atomic.Pointer[tuning]{}
+	copied := *t
+	holder.Store(&copied)
+	return holder
+}
+
+// tuning returns the current tuning of the client
+func (g *GoZaya) tuning() *tuning {
+	return g.tuned.Load()
+}
+
+// tune changes the tuning of the client, copying it on write.
+func (g *GoZaya) tune(change func(t *tuning)) {
+	t := *g.tuned.Load()
+	change(&t)
+	g.tuned.Store(&t)
+}
+
+// Apply applies options to the live client atomically: concurrent calls see either all the
+// changes or none. Only the options tuning the behavior of the calls can be applied, such as
+// WithRateLimiter, WithRetry, WithRateLimitRetry, WithResponseHook, WithRateLimitHook,
+// WithCallHook, WithNamedCallHook, WithSizeBudget, WithEvents, WithLogger, WithDebug, WithRedactor, WithHedging
+// and WithTimeout; caches and connections are kept. When an option changes another setting,
+// Apply returns an error and applies nothing. Clients derived with With are not affected.
+func (g *GoZaya) Apply(options ...Option) error {
+	for {
+		current := g.tuned.Load()
+
+		// the options are applied to an empty client, so that any setting they change
+		// besides the tuning stands out
+		scratch := &GoZaya{tuned: newTuning(current)}
+		if err := applyScratch(scratch, options); err != nil {
+			return err
+		}
+		if field, ok := setField(scratch); ok {
+			return fmt.Errorf("option changing %s cannot be applied to a live client", field)
+		}
+
+		if g.tuned.CompareAndSwap(current, scratch.tuned.Load()) {
+			return nil
+		}
+	}
+}
+
+// applyScratch applies options to an empty client. Options relying on settings of a
+// fully built client fail instead of panicking.
+func applyScratch(scratch *GoZaya, options []Option) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("option cannot be applied to a live client: %v", recovered)
+		}
+	}()
+	for _, option := range options {
+		option(scratch)
+	}
+	return nil
+}
+
+// setField returns the first field other than the tuning set on a client.
+func setField(g *GoZaya) (string, bool) {
+	v := reflect.ValueOf(g).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Name
+		if name != "tuned" && !v.Field(i).IsZero() {
+			return name, true
+		}
+	}
+	return "", false
+}
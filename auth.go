@@ -0,0 +1,65 @@
+package gozaya
+
+import (
+	"github.com/go-resty/resty/v2"
+)
+
+// AuthMode is how the token is sent to the API
+type AuthMode int
+
+const (
+	// AuthBearer sends the token in an "Authorization: Bearer" header. This is the default.
+	AuthBearer AuthMode = iota
+	// AuthAPIKeyHeader sends the token as a static API key in a header.
+	AuthAPIKeyHeader
+	// AuthAPIKeyQuery sends the token as a static API key in a query parameter.
+	AuthAPIKeyQuery
+)
+
+// DefaultAPIKeyHeader is the header used by WithAPIKeyHeader when no name is given
+const DefaultAPIKeyHeader = "X-API-Key"
+
+// DefaultAPIKeyQueryParam is the query parameter used by WithAPIKeyQueryParam when no name is given
+const DefaultAPIKeyQueryParam = "api_key"
+
+// WithAPIKeyHeader sends the token as an API key in the given header, X-API-Key when empty,
+// instead of a bearer token.
+func WithAPIKeyHeader(header string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if header == "" {
+			header = DefaultAPIKeyHeader
+		}
+		g.authMode = AuthAPIKeyHeader
+		g.authKeyName = header
+	}
+}
+
+// WithAPIKeyQueryParam sends the token as an API key in the given query parameter,
+// api_key when empty, instead of a bearer token.
+func WithAPIKeyQueryParam(param string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if param == "" {
+			param = DefaultAPIKeyQueryParam
+		}
+		g.authMode = AuthAPIKeyQuery
+		g.authKeyName = param
+	}
+}
+
+// applyAuth sets the token on the request according to the authentication mode of the client.
+func (g *GoZaya) applyAuth(req *resty.Request, token string) {
+	switch g.authMode {
+	case AuthAPIKeyHeader:
+		req.Token = ""
+		if token != "" {
+			req.SetHeader(g.authKeyName, token)
+		}
+	case AuthAPIKeyQuery:
+		req.Token = ""
+		if token != "" {
+			req.SetQueryParam(g.authKeyName, token)
+		}
+	default:
+		req.SetAuthToken(token)
+	}
+}
@@ -0,0 +1,73 @@
+package gozaya
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// defaultScheme is the scheme of the base paths given without one.
+const defaultScheme = "https"
+
+// BasePathError is returned when the base path of a client is invalid
+type BasePathError struct {
+	BasePath string
+	Reason   string
+}
+
+// Error stringifies the BasePathError
+func (e *BasePathError) Error() string {
+	return fmt.Sprintf("invalid base path %q: %s", e.BasePath, e.Reason)
+}
+
+// normalizeBasePath validates a base path and normalizes it to scheme://host[/path],
+// defaulting to https when the scheme is missing.
+func normalizeBasePath(basePath string) (string, error) {
+	raw := strings.TrimSpace(basePath)
+	if raw == "" {
+		return "", &BasePathError{BasePath: basePath, Reason: "empty"}
+	}
+	if !strings.Contains(raw, "://") {
+		raw = defaultScheme + "://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", &BasePathError{BasePath: basePath, Reason: err.Error()}
+	}
+	switch {
+	case parsed.Scheme != "http" && parsed.Scheme != "https":
+		return "", &BasePathError{BasePath: basePath, Reason: fmt.Sprintf("unsupported scheme %q", parsed.Scheme)}
+	case parsed.Hostname() == "":
+		return "", &BasePathError{BasePath: basePath, Reason: "missing host"}
+	case parsed.RawQuery != "" || parsed.Fragment != "":
+		return "", &BasePathError{BasePath: basePath, Reason: "query and fragment are not allowed"}
+	case parsed.User != nil:
+		return "", &BasePathError{BasePath: basePath, Reason: "credentials are not allowed, use WithToken"}
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	return strings.TrimRight(parsed.String(), urlSeparator), nil
+}
+
+// setBasePath sets the normalized base path of the client, or records why it is invalid.
+func (g *GoZaya) setBasePath(basePath string) {
+	normalized, err := normalizeBasePath(basePath)
+	// only the error of the previous base path is cleared, not the options failing on one
+	if _, ok := g.initErr.(*BasePathError); ok {
+		g.initErr = nil
+	}
+	if err != nil {
+		g.basePath = strings.TrimRight(basePath, urlSeparator)
+		g.initErr = err
+		return
+	}
+	g.basePath = normalized
+}
+
+// Err returns the configuration error of the client, e.g. an invalid base path, which is
+// also returned by every call. It lets the callers fail fast at construction.
+func (g *GoZaya) Err() error {
+	return g.initErr
+}
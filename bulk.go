@@ -0,0 +1,106 @@
+package gozaya
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+const (
+	// bulkDefaultConcurrency is the number of links created concurrently by default.
+	bulkDefaultConcurrency = 4
+	// bulkDefaultChunkSize is the number of links of a chunk by default.
+	bulkDefaultChunkSize = 100
+)
+
+// BulkOptions configures BulkCreateLinks
+type BulkOptions struct {
+	// Concurrency is the number of links of a chunk created concurrently. It defaults to 4.
+	Concurrency int
+	// ChunkSize is the maximum number of links of a chunk, the chunks being sent one after
+	// another. It defaults to 100.
+	ChunkSize int
+	// OnChunk, when set, is called once the links of a chunk were sent, with the index of the
+	// chunk, its results and the errors of its failed links joined, nil when none failed.
+	OnChunk func(ctx context.Context, chunk int, results []BulkResult, err error)
+}
+
+// BulkResult is the outcome of the creation of one link of a bulk request
+type BulkResult struct {
+	// Index is the position of the request in the input
+	Index int
+	// Chunk is the index of the chunk of the request
+	Chunk int
+	Link  *Link
+	Err   error
+}
+
+// BulkCreateLinks creates all the links, splitting the input into chunks of options.ChunkSize
+// links sent one after another, options.Concurrency links of a chunk at a time. The API has
+// no batch endpoint, so each link is created with its own call. The results are returned in
+// input order; a failed link does not stop the others and is reported in its result and in
+// the error of its chunk. The returned error is only set when ctx is done before all the
+// links were sent.
+func (g *GoZaya) BulkCreateLinks(ctx context.Context, token string, links []*GenerateLinkRequest, options BulkOptions) ([]BulkResult, error) {
+	if options.Concurrency <= 0 {
+		options.Concurrency = bulkDefaultConcurrency
+	}
+	if options.ChunkSize <= 0 {
+		options.ChunkSize = bulkDefaultChunkSize
+	}
+
+	results := make([]BulkResult, len(links))
+	for chunk, from := 0, 0; from < len(links); chunk, from = chunk+1, from+options.ChunkSize {
+		to := min(from+options.ChunkSize, len(links))
+		if err := g.bulkCreateChunk(ctx, token, links, results, chunk, from, to, options.Concurrency); err != nil {
+			for index := from; index < len(links); index++ {
+				if results[index].Link == nil && results[index].Err == nil {
+					results[index] = BulkResult{Index: index, Chunk: index / options.ChunkSize, Err: err}
+				}
+			}
+			return results, err
+		}
+
+		if options.OnChunk != nil {
+			errs := make([]error, 0, to-from)
+			for _, result := range results[from:to] {
+				errs = append(errs, result.Err)
+			}
+			options.OnChunk(ctx, chunk, results[from:to], errors.Join(errs...))
+		}
+	}
+	return results, nil
+}
+
+// bulkCreateChunk creates the links from from to to, concurrency at a time, and stores their
+// results. It returns the error of ctx when ctx is done before all the links were sent.
+func (g *GoZaya) bulkCreateChunk(ctx context.Context, token string, links []*GenerateLinkRequest, results []BulkResult, chunk, from, to, concurrency int) error {
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(concurrency, to-from); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				link, err := g.CreateLink(ctx, token, links[index])
+				results[index] = BulkResult{Index: index, Chunk: chunk, Link: link, Err: err}
+			}
+		}()
+	}
+
+	var err error
+	for index := from; index < to; index++ {
+		select {
+		case indexes <- index:
+			continue
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		break
+	}
+	close(indexes)
+	wg.Wait()
+
+	return err
+}
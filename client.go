@@ -2,25 +2,75 @@ package gozaya
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-resty/resty/v2"
-	"github.com/opentracing/opentracing-go"
-	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// GoZaya is a client of the Zaya API. It is configured with options at construction, by
+// NewClient or With, and is then safe for concurrent use by multiple goroutines. The
+// deprecated Config and SetRestyClient are the exceptions: they must not be used once calls
+// started.
 type GoZaya struct {
-	basePath    string
+	// Links, Domains, Spaces and Pixels call the endpoints with the token set by WithToken.
+	Links   *LinksService
+	Domains *DomainsService
+	Spaces  *SpacesService
+	Pixels  *PixelsService
+
+	basePath   string
+	instanceID string
+	// initErr is the configuration error returned by every call
+	initErr     error
 	restyClient *resty.Client
-	Config      struct {
-		CreateLinkEndpoint string
-		GetLinkEndpoint    string
-		RemoveLinkEndpoint string
-	}
+	// ownsHTTPClient is false in derived clients until an option needs their own HTTP client
+	ownsHTTPClient bool
+	userAgent      string
+	// headers are sent with every request
+	headers map[string]string
+	// tokenProvider provides the token of the calls made without an explicit token
+	tokenProvider TokenProvider
+	authMode      AuthMode
+	authKeyName   string
+	signer        RequestSigner
+	// tuned holds the settings that can be changed with Apply
+	tuned *atomic.Pointer[tuning]
+	// requestEncoding is the encoding of the link create and update bodies
+	requestEncoding    RequestEncoding
+	operationEncodings map[Operation]RequestEncoding
+	operationEncoders  map[Operation]BodyEncoder
+	negotiated         *negotiatedEncodings
+	shadow             *shadowReads
+	aliasPolicy        AliasPolicy
+	linkRules          []LinkRule
+	domainRotation     *domainRotation
+	linkDefaults       *GenerateLinkRequest
+	failover           *failover
+	strictDecoding     bool
+	idempotencyKeys    bool
+	accountDefaults    *tokenCache[*AccountDefaults]
+	quotaGuard         *quotaGuard
+	linkCache          *linkCache
+	deletedLinks       *deletedLinks
+	tracer             trace.Tracer
+	correlationHeader  string
+	withoutTracing     bool
+	lastRateLimit      *atomic.Pointer[RateLimit]
+	apiVersion         APIVersion
+	// Config holds the endpoints of the client, resolved at construction.
+	//
+	// Deprecated: set the endpoints with WithEndpoints, WithEndpointOverride or
+	// WithAPIVersion. Changes to Config are still honored, but changing it while calls are
+	// made is a data race.
+	Config Endpoints
 }
 
 const (
@@ -34,33 +84,15 @@ func makeURL(path ...string) string {
 // GetRequest returns a request for calling endpoints.
 func (g *GoZaya) GetRequest(ctx context.Context) *resty.Request {
 	var err HTTPErrorResponse
-	return injectTracingHeaders(
-		ctx, g.restyClient.R().
-			SetContext(ctx).
-			SetError(&err),
-	)
-}
-
-func injectTracingHeaders(ctx context.Context, req *resty.Request) *resty.Request {
-	// look for span in context, do nothing if span is not found
-	span := opentracing.SpanFromContext(ctx)
-	if span == nil {
-		return req
-	}
-
-	// look for tracer in context, use global tracer if not found
-	tracer, ok := ctx.Value(tracerContextKey).(opentracing.Tracer)
-	if !ok || tracer == nil {
-		tracer = opentracing.GlobalTracer()
-	}
-
-	// inject tracing header into request
-	err := tracer.Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header))
-	if err != nil {
+	req := g.restyClient.R().
+		SetContext(withInstance(ctx, g.instanceID)).
+		SetHeader("User-Agent", g.userAgent).
+		SetHeaders(g.headers).
+		SetError(&err)
+	if !g.propagatesTrace(ctx) {
 		return req
 	}
-
-	return req
+	return injectTracingHeaders(ctx, req)
 }
 
 // GetRequestWithBearerAuthNoCache returns a JSON base request configured with an auth token and no-cache header.
@@ -84,19 +116,58 @@ func (g *GoZaya) GetRequestFormData(ctx context.Context, token string) *resty.Re
 		SetHeader("Content-Type", "application/x-www-form-urlencoded")
 }
 
-func NewClient(basePath string, options ...func(*GoZaya)) *GoZaya {
+// NewClient returns a client of the API at basePath, e.g. "https://zaya.io". The scheme
+// defaults to https. When basePath is invalid, Err and every call return a *BasePathError.
+func NewClient(basePath string, options ...Option) *GoZaya {
 	c := GoZaya{
-		basePath:    strings.TrimRight(basePath, urlSeparator),
-		restyClient: resty.New(),
+		restyClient:    resty.New(),
+		ownsHTTPClient: true,
+		userAgent:      defaultUserAgent(),
+		negotiated:     &negotiatedEncodings{},
+		tuned:          newTuning(&tuning{timeout: defaultTimeout}),
+		instanceID:     nextInstanceID(),
+		lastRateLimit:  &atomic.Pointer[RateLimit]{},
+		deletedLinks:   &deletedLinks{ids: make(map[int64]struct{})},
 	}
+	c.setBasePath(basePath)
 
-	c.Config.CreateLinkEndpoint = makeURL("api", "v1", "links")
-	c.Config.GetLinkEndpoint = makeURL("api", "v1", "links")
-	c.Config.RemoveLinkEndpoint = makeURL("api", "v1", "links")
+	c.setAPIVersion(APIv1)
 
 	for _, option := range options {
 		option(&c)
 	}
+	c.checkConfig()
+
+	c.bindServices()
+
+	return &c
+}
+
+// NewClientE creates a new Client like NewClient, returning its configuration error, e.g. an
+// empty or unparseable base path or an invalid endpoint, instead of a client failing every call.
+func NewClientE(basePath string, options ...Option) (*GoZaya, error) {
+	client := NewClient(basePath, options...)
+	if err := client.Err(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// With returns a copy of the client with the given options applied, e.g. to call the API
+// with the token of another tenant. The copy shares the HTTP client, and thus the
+// connection pool, of g; g is left unchanged. It is safe to derive clients concurrently.
+func (g *GoZaya) With(options ...Option) *GoZaya {
+	c := *g
+	c.tuned = newTuning(g.tuning())
+	c.instanceID = nextInstanceID()
+	c.ownsHTTPClient = false
+	c.lastRateLimit = &atomic.Pointer[RateLimit]{}
+	for _, option := range options {
+		option(&c)
+	}
+	c.checkConfig()
+
+	c.bindServices()
 
 	return &c
 }
@@ -108,18 +179,30 @@ func (g *GoZaya) RestyClient() *resty.Client {
 }
 
 // SetRestyClient overwrites the internal resty g.
+// The calls are bounded by the timeout of the client, see WithTimeout.
+//
+// Deprecated: use WithHTTPClient or WithTransport, applied at construction. Replacing the
+// resty client while calls are made is a data race.
 func (g *GoZaya) SetRestyClient(restyClient *resty.Client) {
 	g.restyClient = restyClient
-	g.restyClient.SetTimeout(30 * time.Second)
+	g.ownsHTTPClient = true
 }
 
 func checkForError(resp *resty.Response, err error, errMessage string) error {
 	if err != nil {
-		return &APIError{
+		apiErr := &APIError{
 			Code:    0,
-			Message: errors.Wrap(err, errMessage).Error(),
+			Message: fmt.Sprintf("%s: %s", errMessage, err),
 			Type:    ParseAPIErrType(err),
+			Err:     err,
+		}
+		setRequest(apiErr, resp)
+		// the method and URL prefix the error already, keep the cause only
+		var urlErr *url.Error
+		if apiErr.Method != "" && errors.As(err, &urlErr) {
+			apiErr.Message = urlErr.Err.Error()
 		}
+		return apiErr
 	}
 
 	if resp == nil {
@@ -135,27 +218,52 @@ func checkForError(resp *resty.Response, err error, errMessage string) error {
 		// Parse the error message from the body if available
 		if e, ok := resp.Error().(*HTTPErrorResponse); ok && e.NotEmpty() {
 			msg = fmt.Sprintf("%s: %s", resp.Status(), e)
-		} else if resp.Body() != nil {
-			// If the body contains a message, include it
-			bodyMsg := string(resp.Body())
-			msg = fmt.Sprintf("%s: %s", resp.Status(), bodyMsg)
+		} else if snippet := errorSnippet(resp.Header().Get("Content-Type"), resp.Body()); snippet != "" {
+			// Quote an excerpt of the body, which may be a whole HTML page
+			msg = fmt.Sprintf("%s: %s", resp.Status(), snippet)
 		} else {
 			msg = resp.Status()
 		}
 
-		return &APIError{
-			Code:    resp.StatusCode(),
-			Message: msg,
-			Type:    ParseAPIErrType(err),
+		now := time.Now()
+		apiErr := &APIError{
+			Code:       resp.StatusCode(),
+			Message:    msg,
+			Type:       statusErrType(resp.StatusCode()),
+			RetryAfter: parseRetryAfter(resp.Header(), now),
+			RequestID:  requestID(resp.Header()),
+			Header:     resp.Header(),
+			Body:       resp.Body(),
+		}
+		setRequest(apiErr, resp)
+		switch resp.StatusCode() {
+		case http.StatusTooManyRequests:
+			return newRateLimitError(apiErr, parseRateLimit(resp.Header(), now), now)
+		case http.StatusUnprocessableEntity, http.StatusBadRequest:
+			if validationErr := newValidationError(apiErr, resp.Status(), resp.Body()); validationErr != nil {
+				return validationErr
+			}
 		}
+		return apiErr
 	}
 
 	return nil
 }
 
-func (g *GoZaya) CreateLink(ctx context.Context, token string, link *GenerateLinkRequest) (*ResponseModel, error) {
-	var result ResponseModel
+// setRequest records the method and URL of the request of resp in apiErr.
+func setRequest(apiErr *APIError, resp *resty.Response) {
+	if resp == nil || resp.Request == nil {
+		return
+	}
+	apiErr.Method = resp.Request.Method
+	apiErr.URL = resp.Request.URL
+	if raw := resp.Request.RawRequest; raw != nil && raw.URL != nil {
+		apiErr.URL = raw.URL.String()
+	}
+}
 
+// linkForm converts a link request to form data, skipping the fields that are not set.
+func linkForm(link *GenerateLinkRequest) map[string]string {
 	form := make(map[string]string)
 
 	if link.Url != "" {
@@ -167,11 +275,14 @@ func (g *GoZaya) CreateLink(ctx context.Context, token string, link *GenerateLin
 	if link.Password != "" {
 		form["password"] = link.Password
 	}
-	if link.Disable != 0 {
-		form["disable"] = strconv.Itoa(link.Disable)
+	if link.Space != nil {
+		form["space"] = strconv.Itoa(*link.Space)
+	}
+	if link.Disable != nil {
+		form["disable"] = strconv.Itoa(*link.Disable)
 	}
-	if link.Public != 0 {
-		form["public"] = strconv.Itoa(link.Public)
+	if link.Public != nil {
+		form["public"] = strconv.Itoa(*link.Public)
 	}
 	if link.Description != "" {
 		form["description"] = link.Description
@@ -182,53 +293,350 @@ func (g *GoZaya) CreateLink(ctx context.Context, token string, link *GenerateLin
 	if link.ExpirationTime != "" {
 		form["expiration_time"] = link.ExpirationTime
 	}
-	if link.ExpirationClicks != 0 {
-		form["expiration_clicks"] = strconv.Itoa(link.ExpirationClicks)
+	if link.ExpirationClicks != nil {
+		form["expiration_clicks"] = strconv.Itoa(*link.ExpirationClicks)
 	}
-	if link.Domain != 0 {
-		form["domain"] = strconv.Itoa(link.Domain)
+	if link.Domain != nil {
+		form["domain"] = strconv.Itoa(*link.Domain)
 	}
 	if link.ExpirationUrl != "" {
 		form["expiration_url"] = link.ExpirationUrl
 	}
+	if link.Privacy != nil {
+		form["privacy"] = strconv.Itoa(*link.Privacy)
+	}
+	if link.PrivacyPassword != "" {
+		form["privacy_password"] = link.PrivacyPassword
+	}
+	if link.Favorite != nil {
+		form["favorite"] = strconv.Itoa(*link.Favorite)
+	}
 
-	resp, err := g.GetRequestFormData(ctx, token).
-		SetFormData(form).
-		Post(g.basePath + "/" + g.Config.CreateLinkEndpoint)
+	return form
+}
 
-	if err := checkForError(resp, err, "failed to create link"); err != nil {
+// writeLink sends a link request with the body encoding of the operation.
+func (g *GoZaya) writeLink(ctx context.Context, token string, operation Operation, method string, path string, link *GenerateLinkRequest, errMessage string) (*resty.Response, error) {
+	ctx = withOperation(ctx, operation)
+	if err := g.checkAlias(ctx, link); err != nil {
+		return nil, err
+	}
+	if err := g.checkAccountDefaults(ctx, token, link); err != nil {
+		return nil, err
+	}
+	if err := g.checkLinkPolicy(ctx, operation, link); err != nil {
 		return nil, err
 	}
 
-	if err := json.Unmarshal(resp.Body(), &result); err != nil {
-		return nil, fmt.Errorf("failed to parse create link response: %w", err)
+	encoder, probing := g.encoderFor(operation)
+	resp, err := g.writeBody(ctx, token, encoder, method, path, link, errMessage)
+	if !probing {
+		return resp, err
 	}
 
-	return &result, nil
+	if rejectsEncoding(err) {
+		g.negotiated.set(operation, FormEncoding)
+		return g.writeBody(ctx, token, FormBodyEncoder, method, path, link, errMessage)
+	}
+	if err == nil {
+		g.negotiated.set(operation, JSONEncoding)
+	}
+	return resp, err
 }
 
-func (g *GoZaya) GetLink(ctx context.Context, token string, id string) (*ResponseModel, error) {
-	var result ResponseModel
+// writeBody sends a body encoded with the given encoder.
+func (g *GoZaya) writeBody(ctx context.Context, token string, encoder BodyEncoder, method string, path string, body interface{}, errMessage string) (*resty.Response, error) {
+	encoded, err := encoder.Encode(body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to encode body: %w", errMessage, err)
+	}
+
+	req := g.GetRequestWithBearerAuth(ctx, token).
+		SetHeader("Content-Type", encoder.ContentType()).
+		SetBody(encoded)
 
-	resp, err := g.GetRequestWithBearerAuthNoCache(ctx, token).
-		Get(g.basePath + "/" + g.Config.GetLinkEndpoint + "/" + id)
+	return g.execute(ctx, req, method, path, errMessage)
+}
 
-	if err := checkForError(resp, err, "failed to get link"); err != nil {
+// execute sends the request to the given endpoint path and checks the response for errors.
+// Every API call goes through execute.
+func (g *GoZaya) execute(ctx context.Context, req *resty.Request, method string, path string, errMessage string) (*resty.Response, error) {
+	providedToken := req.Token == "" && callToken(ctx) == ""
+	token, err := g.authorize(ctx, req.Token)
+	if err != nil {
 		return nil, err
 	}
+	g.applyAuth(req, token)
+	g.setIdempotencyKey(ctx, req, method)
 
-	return &result, nil
+	resp, err := g.send(ctx, req, method, path, errMessage)
+
+	if providedToken && resp != nil && resp.StatusCode() == http.StatusUnauthorized {
+		if invalidator, ok := g.tokenProvider.(tokenInvalidator); ok {
+			invalidator.Invalidate()
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// send sends an already authorized request, retrying it according to the retry policy,
+// and checks the response for errors. On API errors the response is returned along with the error.
+func (g *GoZaya) send(ctx context.Context, req *resty.Request, method string, path string, errMessage string) (*resty.Response, error) {
+	ctx = g.correlate(ctx, req)
+	if g.tracer != nil {
+		return g.sendTraced(ctx, req, method, path, errMessage)
+	}
+	return g.sendAttempts(ctx, req, method, path, errMessage)
+}
+
+// sendAttempts sends a request as many times as needed, see send.
+func (g *GoZaya) sendAttempts(ctx context.Context, req *resty.Request, method string, path string, errMessage string) (*resty.Response, error) {
+	if g.initErr != nil {
+		return nil, fmt.Errorf("%s: %w", errMessage, g.initErr)
+	}
+
+	tuning := g.tuning()
+	ctx = withInstance(ctx, g.instanceID)
+
+	start := time.Now()
+	g.reportStart(ctx, tuning, req, method, path, start)
+	attempts, rateLimited, failovers, sends := 1, 0, 0, 0
+	for {
+		base := g.failover.pick(g.basePath)
+		resp, sent, err := g.sendOnce(ctx, tuning, req, method, joinURL(base, path))
+		if sent {
+			sends++
+			if tuning.retry != nil {
+				tuning.retryBudget.record(transientFailure(resp, err))
+			}
+		}
+		if g.failover.report(ctx, g.basePath, base, resp, sent, err) &&
+			retryableMethod(req) && failovers < len(g.failover.fallbacks) {
+			failovers++
+			g.reportRetry(ctx, tuning, req, "failover", sends+1, start, 0, resp, err)
+			continue
+		}
+		if delay, retry := tuning.retry.next(ctx, attempts, req, resp, sent, err); retry &&
+			tuning.withinElapsed(start, delay) && tuning.retryBudget.allows() {
+			g.reportRetry(ctx, tuning, req, "retry", sends+1, start, delay, resp, err)
+			if sleepContext(ctx, delay) == nil {
+				attempts++
+				continue
+			}
+		}
+		if delay, retry := tuning.rateLimitRetry.next(ctx, rateLimited, resp, err); retry &&
+			tuning.withinElapsed(start, delay) {
+			g.reportRetry(ctx, tuning, req, "rate limited", sends+1, start, delay, resp, err)
+			if sleepContext(ctx, delay) == nil {
+				rateLimited++
+				continue
+			}
+		}
+
+		if err := checkForError(resp, err, errMessage); err != nil {
+			err = g.redactError(err, req)
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				apiErr.Attempts = sends
+				apiErr.Operation = OperationFromContext(ctx)
+				apiErr.InstanceID = g.instanceID
+				apiErr.CorrelationID = CorrelationIDFromContext(ctx)
+				if tuning.errorHook != nil {
+					tuning.errorHook(ctx, apiErr)
+				}
+			}
+			g.reportCall(ctx, tuning, method, start, sends, resp, err)
+			return resp, err
+		}
+
+		g.reportCall(ctx, tuning, method, start, sends, resp, nil)
+		return resp, nil
+	}
+}
+
+// sendOnce makes one attempt at sending a request. sent is false when the request
+// could not be sent, e.g. because ctx was done while waiting for the rate limiter.
+func (g *GoZaya) sendOnce(ctx context.Context, tuning *tuning, req *resty.Request, method string, endpoint string) (*resty.Response, bool, error) {
+	if tuning.rateLimiter != nil {
+		if err := tuning.rateLimiter.Wait(ctx); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if g.signer != nil {
+		req.Method = method
+		if err := g.sign(req, endpoint); err != nil {
+			return nil, false, err
+		}
+	}
+
+	release, err := tuning.concurrency.acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer release()
+
+	ctx, cancel := attemptContext(ctx, tuning, req)
+	defer cancel()
+
+	var resp *resty.Response
+	sentAt := time.Now()
+	if method == http.MethodGet && tuning.hedgeThreshold > 0 {
+		resp, err = g.executeHedged(ctx, tuning, req, endpoint)
+	} else {
+		resp, err = req.Execute(method, endpoint)
+	}
+
+	if resp != nil && resp.RawResponse != nil {
+		g.reportResponse(ctx, resp)
+	}
+	g.reportAttempt(ctx, tuning, method, endpoint, sentAt, resp, err)
+	g.dump(ctx, tuning, req, resp, err)
+
+	return resp, true, err
+}
+
+func (g *GoZaya) CreateLink(ctx context.Context, token string, link *GenerateLinkRequest) (*Link, error) {
+	var result linkResponse
+
+	if err := g.checkQuota(ctx, token); err != nil {
+		return nil, err
+	}
+
+	path, err := endpointPath("CreateLinkEndpoint", g.Config.CreateLinkEndpoint, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create link: %w", err)
+	}
+
+	resp, err := g.writeLink(ctx, token, OperationCreateLink, http.MethodPost, path, g.applyLinkDefaults(g.rotateDomain(link)), "failed to create link")
+	if err != nil {
+		return nil, err
+	}
+	g.countCreatedLink(ctx, token)
+
+	if err := g.decodeResponse(resp, &result, "failed to parse create link response"); err != nil {
+		return nil, err
+	}
+	if result.Data != nil {
+		g.InvalidateCachedAlias(result.Data.Alias)
+	}
+
+	return result.Data, nil
+}
+
+// UpdateLink updates the link with the given ID. Only the fields set in link are changed.
+func (g *GoZaya) UpdateLink(ctx context.Context, token string, id string, link *GenerateLinkRequest) (*Link, error) {
+	var result linkResponse
+
+	path, err := endpointPath("UpdateLinkEndpoint", g.Config.UpdateLinkEndpoint, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update link: %w", err)
+	}
+
+	resp, err := g.writeLink(ctx, token, OperationUpdateLink, http.MethodPut, path, link, "failed to update link")
+	if err != nil {
+		return nil, err
+	}
+	g.InvalidateCachedLink(id)
+	if link != nil {
+		g.InvalidateCachedAlias(link.Alias)
+	}
+
+	if err := g.decodeResponse(resp, &result, "failed to parse update link response"); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// get performs a GET request against the given endpoint path and mirrors it
+// to the shadow instance when shadow reads are enabled.
+func (g *GoZaya) get(ctx context.Context, token string, path string, queryParams map[string]string, errMessage string) (*resty.Response, error) {
+	req := g.GetRequestWithBearerAuthNoCache(ctx, token).
+		SetQueryParams(queryParams)
+
+	resp, err := g.execute(ctx, req, http.MethodGet, path, errMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	g.shadowRead(ctx, token, path, queryParams, resp)
+
+	return resp, nil
+}
+
+func (g *GoZaya) GetLink(ctx context.Context, token string, id string) (*Link, error) {
+	if g.linkCache != nil {
+		return g.getCachedLink(ctx, token, id)
+	}
+	return g.fetchLink(ctx, token, id)
+}
+
+// fetchLink gets a link from the API, bypassing the link cache.
+func (g *GoZaya) fetchLink(ctx context.Context, token string, id string) (*Link, error) {
+	var result linkResponse
+
+	path, err := endpointPath("GetLinkEndpoint", g.Config.GetLinkEndpoint, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link: %w", err)
+	}
+
+	ctx = withOperation(ctx, OperationGetLink)
+	resp, err := g.get(ctx, token, path, nil, "failed to get link")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.decodeResponse(resp, &result, "failed to parse get link response"); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
 }
 
 func (g *GoZaya) RemoveLink(ctx context.Context, token string, id string) (*RemoveLinkResponse, error) {
 	var result RemoveLinkResponse
 
-	resp, err := g.GetRequestWithBearerAuthNoCache(ctx, token).
-		Delete(g.basePath + "/" + g.Config.RemoveLinkEndpoint + "/" + id)
+	path, err := endpointPath("RemoveLinkEndpoint", g.Config.RemoveLinkEndpoint, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove link: %w", err)
+	}
 
-	if err := checkForError(resp, err, "failed to remove link"); err != nil {
+	ctx = withOperation(ctx, OperationRemoveLink)
+	resp, err := g.execute(ctx, g.GetRequestWithBearerAuthNoCache(ctx, token), http.MethodDelete, path, "failed to remove link")
+	if err != nil {
+		return nil, err
+	}
+	g.InvalidateCachedLink(id)
+
+	if err := g.decodeResponse(resp, &result, "failed to parse remove link response"); err != nil {
 		return nil, err
 	}
 
 	return &result, nil
 }
+
+// ListLinks returns a page of the links of the account matching the given params,
+// along with the pagination metadata.
+func (g *GoZaya) ListLinks(ctx context.Context, token string, params GetLinksParams) ([]*Link, *Page, error) {
+	return listPage[*Link](withOperation(ctx, OperationListLinks), g, token, "ListLinksEndpoint", g.Config.ListLinksEndpoint, params, "list links")
+}
+
+// ListLinksByDomain returns the links created on the given branded domain.
+// Use params.Page to walk through the links of a domain with many links.
+func (g *GoZaya) ListLinksByDomain(ctx context.Context, token string, domainID int, params GetLinksParams) ([]*Link, *Page, error) {
+	params.Domain = IntP(domainID)
+	return g.ListLinks(ctx, token, params)
+}
+
+// ListLinksByPixel returns the links firing the given retargeting pixel.
+// Use params.Page to walk through the links of a pixel used by many links.
+func (g *GoZaya) ListLinksByPixel(ctx context.Context, token string, pixelID int, params GetLinksParams) ([]*Link, *Page, error) {
+	params.Pixel = IntP(pixelID)
+	return g.ListLinks(ctx, token, params)
+}
@@ -0,0 +1,282 @@
+package gozaya
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erfandiakoo/go-zaya/fixtures"
+)
+
+// linkServer returns a test server answering with the status returned by status for the nth
+// request when it is not 0, and with the GetLink fixture otherwise. It counts the requests.
+func linkServer(t *testing.T, status func(w http.ResponseWriter, r *http.Request, n int) int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		w.Header().Set("Content-Type", "application/json")
+		if code := status(w, r, n); code != 0 {
+			w.WriteHeader(code)
+			_, _ = w.Write([]byte(`{"message":"failed"}`))
+			return
+		}
+		_, _ = w.Write(fixtures.MustLoad(fixtures.GetLink))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		status   int
+		wantErr  bool
+		want     int32
+	}{
+		{name: "success", failures: 0, status: http.StatusServiceUnavailable, want: 1},
+		{name: "recovers", failures: 2, status: http.StatusServiceUnavailable, want: 3},
+		{name: "exhausted", failures: 10, status: http.StatusBadGateway, wantErr: true, want: 4},
+		{name: "not transient", failures: 10, status: http.StatusNotFound, wantErr: true, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := linkServer(t, func(w http.ResponseWriter, r *http.Request, n int) int {
+				if n <= tt.failures {
+					return tt.status
+				}
+				return 0
+			})
+			client := NewClient(server.URL, WithRetry(4, time.Millisecond, time.Millisecond))
+
+			_, err := client.GetLink(context.Background(), "token", "1042")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := requests.Load(); got != tt.want {
+				t.Errorf("requests = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientRetryBudget(t *testing.T) {
+	server, requests := linkServer(t, func(w http.ResponseWriter, r *http.Request, n int) int {
+		return http.StatusServiceUnavailable
+	})
+	// retries are allowed while more than 2 of the 4 tokens are left
+	client := NewClient(server.URL,
+		WithRetry(10, time.Millisecond, time.Millisecond),
+		WithRetryBudget(NewRetryBudget(4, 0)))
+	ctx := context.Background()
+
+	if _, err := client.GetLink(ctx, "token", "1042"); err == nil {
+		t.Fatal("GetLink() error = nil, want an error")
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+
+	if _, err := client.GetLink(ctx, "token", "1042"); err == nil {
+		t.Fatal("GetLink() error = nil, want an error")
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests after the budget is exhausted = %d, want 3", got)
+	}
+}
+
+func TestClientRateLimitRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxWait      time.Duration
+		wantErr      bool
+		wantRequests int32
+		wantWait     time.Duration
+	}{
+		{name: "waits", maxWait: 2 * time.Second, wantRequests: 2, wantWait: time.Second},
+		{name: "too long", maxWait: 500 * time.Millisecond, wantErr: true, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := linkServer(t, func(w http.ResponseWriter, r *http.Request, n int) int {
+				if n == 1 {
+					w.Header().Set("Retry-After", "1")
+					return http.StatusTooManyRequests
+				}
+				return 0
+			})
+			client := NewClient(server.URL, WithRateLimitRetry(3, tt.maxWait))
+
+			start := time.Now()
+			_, err := client.GetLink(context.Background(), "token", "1042")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var rateLimitErr *RateLimitError
+			if tt.wantErr && !errors.As(err, &rateLimitErr) {
+				t.Errorf("GetLink() error = %T, want a *RateLimitError", err)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+			if elapsed := time.Since(start); elapsed < tt.wantWait {
+				t.Errorf("elapsed = %v, want at least %v", elapsed, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestClientInvalidatesRejectedToken(t *testing.T) {
+	server, _ := linkServer(t, func(w http.ResponseWriter, r *http.Request, n int) int {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			return http.StatusUnauthorized
+		}
+		return 0
+	})
+
+	var fetches atomic.Int32
+	provider := NewRefreshingTokenProvider(func(ctx context.Context) (string, time.Time, error) {
+		if fetches.Add(1) == 1 {
+			return "stale", time.Time{}, nil
+		}
+		return "fresh", time.Time{}, nil
+	}, 0)
+	client := NewClient(server.URL, WithTokenProvider(provider))
+	ctx := context.Background()
+
+	if _, err := client.GetLink(ctx, "", "1042"); err == nil {
+		t.Fatal("GetLink() with a rejected token error = nil, want an error")
+	}
+	if _, err := client.GetLink(ctx, "", "1042"); err != nil {
+		t.Fatalf("GetLink() after invalidation error = %v", err)
+	}
+	if got := fetches.Load(); got != 2 {
+		t.Errorf("fetches = %d, want 2", got)
+	}
+
+	// an explicit token is not the one of the provider, which is kept
+	if _, err := client.GetLink(ctx, "other", "1042"); err == nil {
+		t.Fatal("GetLink() with a rejected explicit token error = nil, want an error")
+	}
+	if _, err := client.GetLink(ctx, "", "1042"); err != nil {
+		t.Fatalf("GetLink() error = %v", err)
+	}
+	if got := fetches.Load(); got != 2 {
+		t.Errorf("fetches after a rejected explicit token = %d, want 2", got)
+	}
+}
+
+func TestClientRefreshesTokenOnce(t *testing.T) {
+	server, _ := linkServer(t, func(w http.ResponseWriter, r *http.Request, n int) int { return 0 })
+
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	provider := NewRefreshingTokenProvider(func(ctx context.Context) (string, time.Time, error) {
+		fetches.Add(1)
+		<-release
+		return "token", time.Now().Add(time.Hour), nil
+	}, 0)
+	client := NewClient(server.URL, WithTokenProvider(provider))
+
+	const calls = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, calls)
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetLink(context.Background(), "", "1042")
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetLink() error = %v", err)
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("fetches = %d, want 1", got)
+	}
+}
+
+func TestClientHedgingCancelsSlowRequest(t *testing.T) {
+	canceled := make(chan struct{})
+	server, requests := linkServer(t, func(w http.ResponseWriter, r *http.Request, n int) int {
+		if n == 1 {
+			<-r.Context().Done()
+			close(canceled)
+		}
+		return 0
+	})
+	client := NewClient(server.URL, WithHedging(20*time.Millisecond))
+
+	if _, err := client.GetLink(context.Background(), "token", "1042"); err != nil {
+		t.Fatalf("GetLink() error = %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Error("the slow request was not canceled")
+	}
+}
+
+func TestClientMaxConcurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+	}{
+		{name: "plain", options: []Option{WithMaxConcurrency(2)}},
+		{name: "hedged", options: []Option{WithMaxConcurrency(2), WithHedging(5 * time.Millisecond)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, peak atomic.Int32
+			server, _ := linkServer(t, func(w http.ResponseWriter, r *http.Request, n int) int {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					seen := peak.Load()
+					if current <= seen || peak.CompareAndSwap(seen, current) {
+						break
+					}
+				}
+				time.Sleep(30 * time.Millisecond)
+				return 0
+			})
+			client := NewClient(server.URL, tt.options...)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := client.GetLink(context.Background(), "token", "1042"); err != nil {
+						t.Errorf("GetLink() error = %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got := peak.Load(); got != 2 {
+				t.Errorf("peak requests in flight = %d, want 2", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package gozaya
+
+import (
+	"context"
+)
+
+// concurrencyLimit is a semaphore bounding the requests in flight
+type concurrencyLimit chan struct{}
+
+// WithMaxConcurrency bounds the requests in flight to n, the others waiting for a slot or
+// until their context is done, e.g. so that a burst of jobs does not open hundreds of
+// connections. Hedged requests count against the limit too, see WithHedging. The limit is
+// shared by the clients derived with With. Zero or less removes the limit.
+func WithMaxConcurrency(n int) func(*GoZaya) {
+	return func(g *GoZaya) {
+		var limit concurrencyLimit
+		if n > 0 {
+			limit = make(concurrencyLimit, n)
+		}
+		g.tune(func(t *tuning) { t.concurrency = limit })
+	}
+}
+
+// acquire waits for a slot, and returns the function releasing it.
+func (l concurrencyLimit) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	select {
+	case l <- struct{}{}:
+		return func() { <-l }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// tryAcquire takes a slot if one is free without waiting, and returns the function releasing it.
+func (l concurrencyLimit) tryAcquire() (func(), bool) {
+	if l == nil {
+		return func() {}, true
+	}
+	select {
+	case l <- struct{}{}:
+		return func() { <-l }, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,62 @@
+package gozaya
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// DefaultCorrelationHeader is the header of the correlation IDs unless set with WithCorrelationID
+const DefaultCorrelationHeader = "X-Request-ID"
+
+// WithCorrelationID sends a correlation ID with every call in header, DefaultCorrelationHeader
+// when empty, so that the call can be followed across systems. The ID is the one of the
+// context of the call, see WithCallCorrelationID, or a random one generated per call, and is
+// the same for all the retries of a call. It is reported in the errors, the response
+// metadata, the logs and the spans of the call, along with the request ID of the server.
+func WithCorrelationID(header string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if header == "" {
+			header = DefaultCorrelationHeader
+		}
+		g.correlationHeader = http.CanonicalHeaderKey(header)
+	}
+}
+
+type correlationContextKey struct{}
+
+// WithCallCorrelationID returns a context sending id as the correlation ID of the calls made
+// with it, e.g. the ID of the incoming request being served, when WithCorrelationID is set.
+func WithCallCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationContextKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID of the calls made with ctx, empty when not set
+func CorrelationIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(correlationContextKey{}).(string)
+	return id
+}
+
+// correlate sets the correlation ID of a call on req, returning the context telling it.
+func (g *GoZaya) correlate(ctx context.Context, req *resty.Request) context.Context {
+	if g.correlationHeader == "" {
+		return ctx
+	}
+	id := CorrelationIDFromContext(ctx)
+	if id == "" {
+		id = newCorrelationID()
+		ctx = WithCallCorrelationID(ctx, id)
+	}
+	req.SetHeader(g.correlationHeader, id)
+	return ctx
+}
+
+// newCorrelationID returns a random correlation ID
+func newCorrelationID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
@@ -0,0 +1,85 @@
+package gozaya
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// redacted replaces the value of a credential in its printed forms.
+const redacted = "[REDACTED]"
+
+// Credential holds a secret such as an API token. Its printed, JSON and log forms are
+// redacted, and Destroy zeroes the secret so that rotated tokens do not linger in memory.
+// Zeroing is best effort: strings returned by Reveal are immutable copies the client cannot
+// clear, so they should not be retained.
+type Credential struct {
+	mu     sync.RWMutex
+	secret []byte
+}
+
+// NewCredential returns a credential holding a copy of secret
+func NewCredential(secret string) *Credential {
+	return &Credential{secret: []byte(secret)}
+}
+
+// Reveal returns the secret, or an empty string once the credential was destroyed
+func (c *Credential) Reveal() string {
+	if c == nil {
+		return ""
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return string(c.secret)
+}
+
+// Empty reports whether the credential holds no secret
+func (c *Credential) Empty() bool {
+	if c == nil {
+		return true
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.secret) == 0
+}
+
+// Destroy zeroes the secret
+func (c *Credential) Destroy() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.secret)
+	c.secret = nil
+}
+
+// String returns a redacted placeholder
+func (c *Credential) String() string {
+	return redacted
+}
+
+// GoString returns a redacted placeholder, used by %#v
+func (c *Credential) GoString() string {
+	return redacted
+}
+
+// Format writes a redacted placeholder whatever the verb
+func (c *Credential) Format(f fmt.State, verb rune) {
+	_, _ = f.Write([]byte(redacted))
+}
+
+// MarshalJSON encodes the credential as a redacted placeholder
+func (c *Credential) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redacted + `"`), nil
+}
+
+// MarshalText encodes the credential as a redacted placeholder
+func (c *Credential) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+// LogValue logs the credential as a redacted placeholder
+func (c *Credential) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
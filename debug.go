@@ -0,0 +1,130 @@
+package gozaya
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// maskedHeaders are the headers whose values are never dumped
+var maskedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// debugWriter serializes the dumps of concurrent calls
+type debugWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// WithDebug dumps every request and response of the client to w, headers and bodies
+// included, for troubleshooting. The credentials, the authorization and cookie headers and
+// the values matched by the redactor, such as passwords, are masked. See WithCallDebug to
+// dump the requests of some calls only. A nil w disables the dumps.
+func WithDebug(w io.Writer) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.debug = newDebugWriter(w) })
+	}
+}
+
+type debugContextKey struct{}
+
+// WithCallDebug returns a context dumping the requests and responses of the calls made with
+// it to w, like WithDebug.
+func WithCallDebug(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, debugContextKey{}, newDebugWriter(w))
+}
+
+func newDebugWriter(w io.Writer) *debugWriter {
+	if w == nil {
+		return nil
+	}
+	return &debugWriter{w: w}
+}
+
+// debugWriter returns the writer of the dumps of a call, nil when not dumping.
+func (t *tuning) debugWriter(ctx context.Context) *debugWriter {
+	if w, ok := ctx.Value(debugContextKey{}).(*debugWriter); ok && w != nil {
+		return w
+	}
+	return t.debug
+}
+
+// dump writes the sanitized request and response of an attempt.
+func (g *GoZaya) dump(ctx context.Context, tuning *tuning, req *resty.Request, resp *resty.Response, err error) {
+	w := tuning.debugWriter(ctx)
+	if w == nil {
+		return
+	}
+	if resp != nil && resp.Request != nil {
+		// the request of a hedged call is a copy of req
+		req = resp.Request
+	}
+	secrets := requestSecrets(g, req)
+	masked := maskedHeaders
+	if g.authMode == AuthAPIKeyHeader {
+		masked = append(slices.Clip(masked), g.authKeyName)
+	}
+	sanitize := func(text string) string {
+		return g.redact(text, secrets...)
+	}
+
+	var b strings.Builder
+	if raw := req.RawRequest; raw != nil {
+		fmt.Fprintf(&b, "> %s %s\n", raw.Method, sanitize(raw.URL.String()))
+		writeHeaders(&b, "> ", raw.Header, masked, sanitize)
+		if raw.GetBody != nil {
+			if body, bodyErr := raw.GetBody(); bodyErr == nil {
+				content, _ := io.ReadAll(body)
+				_ = body.Close()
+				writeBody(&b, "> ", content, sanitize)
+			}
+		}
+	} else {
+		fmt.Fprintf(&b, "> %s %s\n", req.Method, sanitize(req.URL))
+	}
+
+	if resp != nil && resp.RawResponse != nil {
+		fmt.Fprintf(&b, "< %s %s (%s)\n", resp.Proto(), resp.Status(), resp.Time().Round(time.Microsecond))
+		writeHeaders(&b, "< ", resp.Header(), masked, sanitize)
+		writeBody(&b, "< ", resp.Body(), sanitize)
+	}
+	if err != nil {
+		fmt.Fprintf(&b, "< error: %s\n", sanitize(err.Error()))
+	}
+	b.WriteString("\n")
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, _ = io.WriteString(w.w, b.String())
+}
+
+// writeHeaders writes sorted headers, masking the values of the masked ones.
+func writeHeaders(b *strings.Builder, prefix string, header http.Header, masked []string, sanitize func(string) string) {
+	for _, name := range slices.Sorted(maps.Keys(header)) {
+		for _, value := range header[name] {
+			if slices.ContainsFunc(masked, func(m string) bool { return strings.EqualFold(m, name) }) {
+				value = redacted
+			}
+			fmt.Fprintf(b, "%s%s: %s\n", prefix, name, sanitize(value))
+		}
+	}
+}
+
+// writeBody writes a body after a blank line, each of its lines prefixed.
+func writeBody(b *strings.Builder, prefix string, body []byte, sanitize func(string) string) {
+	if len(body) == 0 {
+		return
+	}
+	b.WriteString(strings.TrimRight(prefix, " ") + "\n")
+	for line := range strings.SplitSeq(sanitize(string(bytes.TrimRight(body, "\n"))), "\n") {
+		b.WriteString(prefix + line + "\n")
+	}
+}
@@ -0,0 +1,182 @@
+package gozaya
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// DecodeError is returned when a response body cannot be decoded into the expected model.
+// In strict decoding mode it is also returned for unknown and missing required fields.
+type DecodeError struct {
+	// Field is the JSON path of the offending field, empty for errors affecting the whole body.
+	Field string
+	// Reason describes what is wrong with the field.
+	Reason string
+	// Err is the underlying JSON error, if any.
+	Err error
+
+	message string
+}
+
+// Error stringifies the DecodeError
+func (e *DecodeError) Error() string {
+	var res strings.Builder
+	res.WriteString(e.message)
+	if e.Field != "" {
+		res.WriteString(": ")
+		res.WriteString(e.Field)
+	}
+	if e.Reason != "" {
+		res.WriteString(": ")
+		res.WriteString(e.Reason)
+	}
+	if e.Err != nil {
+		res.WriteString(": ")
+		res.WriteString(e.Err.Error())
+	}
+	return res.String()
+}
+
+// Unwrap returns the underlying JSON error
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// WithStrictDecoding makes the client reject responses holding fields unknown to the models
+// or missing required fields, returning a *DecodeError instead of silently zeroed fields.
+func WithStrictDecoding() func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.strictDecoding = true
+	}
+}
+
+// jsonShaper is implemented by the models decoded through an intermediate wire type.
+// jsonShape returns a value of the wire type, used to validate the fields in strict mode.
+type jsonShaper interface {
+	jsonShape() interface{}
+}
+
+var (
+	jsonShaperType  = reflect.TypeOf((*jsonShaper)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// decodeResponse unmarshals the JSON body of a successful response into result.
+// Empty bodies are accepted and leave result untouched.
+func (g *GoZaya) decodeResponse(resp *resty.Response, result interface{}, errMessage string) error {
+	body := resp.Body()
+	if len(body) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return &DecodeError{message: errMessage, Err: err}
+	}
+
+	if !g.strictDecoding {
+		return nil
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return &DecodeError{message: errMessage, Err: err}
+	}
+	if err := checkShape(document, reflect.TypeOf(result), ""); err != nil {
+		err.message = errMessage
+		return err
+	}
+	return nil
+}
+
+// checkShape validates a decoded JSON document against the Go type it was decoded into,
+// reporting unknown fields and missing required fields.
+func checkShape(document interface{}, t reflect.Type, path string) *DecodeError {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonShaperType) {
+		shaper := reflect.New(t).Interface().(jsonShaper)
+		return checkShape(document, reflect.TypeOf(shaper.jsonShape()), path)
+	}
+	if document == nil || t == rawMessageType || t.Kind() == reflect.Interface ||
+		reflect.PointerTo(t).Implements(unmarshalerType) {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := document.(map[string]interface{})
+		if !ok {
+			return &DecodeError{Field: path, Reason: "expected an object"}
+		}
+		return checkStruct(object, t, path)
+	case reflect.Slice, reflect.Array:
+		items, ok := document.([]interface{})
+		if !ok {
+			return &DecodeError{Field: path, Reason: "expected an array"}
+		}
+		for i, item := range items {
+			if err := checkShape(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		object, ok := document.(map[string]interface{})
+		if !ok {
+			return &DecodeError{Field: path, Reason: "expected an object"}
+		}
+		for key, value := range object {
+			if err := checkShape(value, t.Elem(), joinJSONPath(path, key)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkStruct(object map[string]interface{}, t reflect.Type, path string) *DecodeError {
+	known := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[name] = true
+
+		value, present := object[name]
+		if field.Tag.Get("required") == "true" && (!present || value == nil) {
+			return &DecodeError{Field: joinJSONPath(path, name), Reason: "missing required field"}
+		}
+		if present {
+			if err := checkShape(value, field.Type, joinJSONPath(path, name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for key := range object {
+		if !known[key] {
+			return &DecodeError{Field: joinJSONPath(path, key), Reason: "unknown field"}
+		}
+	}
+	return nil
+}
+
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
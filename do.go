@@ -0,0 +1,45 @@
+package gozaya
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// Response is the response of a raw API call
+type Response struct {
+	ResponseMeta
+	Body []byte
+}
+
+// Do calls an API endpoint the client does not wrap yet, with the same authentication,
+// tracing, error handling and decoding as the wrapped endpoints. path is relative to the
+// base path, e.g. "api/v1/stats/42". body is sent form-encoded when it is a map[string]string
+// or url.Values and as JSON otherwise; it is omitted when nil. The response body is
+// decoded into out when out is not nil.
+func (g *GoZaya) Do(ctx context.Context, token string, method string, path string, body interface{}, out interface{}) (*Response, error) {
+	ctx = withOperation(ctx, OperationRaw)
+	req := g.GetRequestWithBearerAuthNoCache(ctx, token)
+	switch body := body.(type) {
+	case nil:
+	case map[string]string:
+		req = g.GetRequestFormData(ctx, token).SetFormData(body)
+	case url.Values:
+		req = g.GetRequestFormData(ctx, token).SetFormDataFromValues(body)
+	default:
+		req = g.GetRequestWithBearerAuth(ctx, token).SetBody(body)
+	}
+
+	resp, err := g.execute(ctx, req, strings.ToUpper(method), strings.TrimLeft(path, urlSeparator), "failed to call "+method+" "+path)
+	if err != nil {
+		return nil, err
+	}
+
+	if out != nil {
+		if err := g.decodeResponse(resp, out, "failed to parse "+method+" "+path+" response"); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Response{ResponseMeta: *newResponseMeta(resp), Body: resp.Body()}, nil
+}
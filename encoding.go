@@ -0,0 +1,175 @@
+package gozaya
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// RequestEncoding is the encoding of the bodies sent to create and update links
+type RequestEncoding int
+
+const (
+	// FormEncoding sends application/x-www-form-urlencoded bodies. This is the default.
+	FormEncoding RequestEncoding = iota
+	// JSONEncoding sends application/json bodies.
+	JSONEncoding
+	// AutoEncoding sends JSON bodies and falls back to form-encoded bodies for the operations
+	// the server rejects JSON for with 415 Unsupported Media Type. The outcome is remembered
+	// per operation, so the probing only costs one request per operation.
+	AutoEncoding
+)
+
+// BodyEncoder encodes request bodies
+type BodyEncoder interface {
+	// ContentType is the Content-Type header of the encoded bodies.
+	ContentType() string
+	// Encode encodes a request body.
+	Encode(body interface{}) ([]byte, error)
+}
+
+var (
+	// FormBodyEncoder encodes bodies as application/x-www-form-urlencoded. It accepts
+	// *GenerateLinkRequest, map[string]string and url.Values bodies.
+	FormBodyEncoder BodyEncoder = formBodyEncoder{}
+	// JSONBodyEncoder encodes bodies as application/json.
+	JSONBodyEncoder BodyEncoder = jsonBodyEncoder{}
+)
+
+type formBodyEncoder struct{}
+
+func (formBodyEncoder) ContentType() string {
+	return "application/x-www-form-urlencoded"
+}
+
+func (formBodyEncoder) Encode(body interface{}) ([]byte, error) {
+	values := url.Values{}
+	switch body := body.(type) {
+	case *GenerateLinkRequest:
+		for key, value := range linkForm(body) {
+			values.Set(key, value)
+		}
+	case map[string]string:
+		for key, value := range body {
+			values.Set(key, value)
+		}
+	case url.Values:
+		values = body
+	default:
+		return nil, fmt.Errorf("cannot form-encode a %T body", body)
+	}
+	return []byte(values.Encode()), nil
+}
+
+type jsonBodyEncoder struct{}
+
+func (jsonBodyEncoder) ContentType() string {
+	return "application/json"
+}
+
+func (jsonBodyEncoder) Encode(body interface{}) ([]byte, error) {
+	return json.Marshal(body)
+}
+
+// WithRequestEncoding sets the encoding of the link create and update bodies.
+func WithRequestEncoding(encoding RequestEncoding) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.requestEncoding = encoding
+	}
+}
+
+// WithJSONRequests makes CreateLink and UpdateLink send application/json bodies
+// instead of form-encoded data.
+func WithJSONRequests() func(*GoZaya) {
+	return WithRequestEncoding(JSONEncoding)
+}
+
+// WithOperationEncoding overrides the request encoding of one operation,
+// e.g. to keep form-encoded creates against a server only accepting those.
+func WithOperationEncoding(operation Operation, encoding RequestEncoding) func(*GoZaya) {
+	return func(g *GoZaya) {
+		// copy the map, which is shared with the parent of a derived client
+		encodings := maps.Clone(g.operationEncodings)
+		if encodings == nil {
+			encodings = make(map[Operation]RequestEncoding)
+		}
+		encodings[operation] = encoding
+		g.operationEncodings = encodings
+	}
+}
+
+// WithOperationEncoder sets a custom body encoder for one operation.
+// It takes precedence over the request encoding of the operation.
+func WithOperationEncoder(operation Operation, encoder BodyEncoder) func(*GoZaya) {
+	return func(g *GoZaya) {
+		encoders := maps.Clone(g.operationEncoders)
+		if encoders == nil {
+			encoders = make(map[Operation]BodyEncoder)
+		}
+		encoders[operation] = encoder
+		g.operationEncoders = encoders
+	}
+}
+
+// negotiatedEncodings remembers the encodings negotiated by AutoEncoding
+type negotiatedEncodings struct {
+	mu        sync.Mutex
+	encodings map[Operation]RequestEncoding
+}
+
+func (n *negotiatedEncodings) get(operation Operation) (RequestEncoding, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	encoding, ok := n.encodings[operation]
+	return encoding, ok
+}
+
+func (n *negotiatedEncodings) set(operation Operation, encoding RequestEncoding) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.encodings == nil {
+		n.encodings = make(map[Operation]RequestEncoding)
+	}
+	n.encodings[operation] = encoding
+}
+
+// encodingFor returns the request encoding of an operation
+func (g *GoZaya) encodingFor(operation Operation) RequestEncoding {
+	if encoding, ok := g.operationEncodings[operation]; ok {
+		return encoding
+	}
+	return g.requestEncoding
+}
+
+// encoderFor returns the body encoder of an operation, and whether it is being probed by
+// AutoEncoding and may be retried with the form encoder.
+func (g *GoZaya) encoderFor(operation Operation) (BodyEncoder, bool) {
+	if encoder, ok := g.operationEncoders[operation]; ok {
+		return encoder, false
+	}
+
+	switch g.encodingFor(operation) {
+	case JSONEncoding:
+		return JSONBodyEncoder, false
+	case AutoEncoding:
+		if encoding, ok := g.negotiated.get(operation); ok {
+			if encoding == JSONEncoding {
+				return JSONBodyEncoder, false
+			}
+			return FormBodyEncoder, false
+		}
+		return JSONBodyEncoder, true
+	default:
+		return FormBodyEncoder, false
+	}
+}
+
+// rejectsEncoding reports whether the error tells that the server does not accept the body encoding
+func rejectsEncoding(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusUnsupportedMediaType
+}
@@ -0,0 +1,185 @@
+package gozaya
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// idPlaceholder is the placeholder of the ID in the templates of the endpoints taking one,
+// e.g. "api/v1/links/{id}/details". The ID is appended to the endpoints without it.
+const idPlaceholder = "{id}"
+
+// EndpointError is returned when an endpoint is empty or invalid
+type EndpointError struct {
+	// Endpoint is the name of the Endpoints field, e.g. "GetLinkEndpoint"
+	Endpoint string
+	Value    string
+	Reason   string
+}
+
+// Error stringifies the EndpointError
+func (e *EndpointError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %s", e.Endpoint, e.Value, e.Reason)
+}
+
+// endpointSpec is an endpoint of the client
+type endpointSpec struct {
+	name   string
+	value  *string
+	takeID bool
+}
+
+// endpointSpecs lists the endpoints of the client.
+func (g *GoZaya) endpointSpecs() []endpointSpec {
+	return g.Config.specs()
+}
+
+// specs lists the endpoints, in the order of their fields.
+func (e *Endpoints) specs() []endpointSpec {
+	return []endpointSpec{
+		{"CreateLinkEndpoint", &e.CreateLinkEndpoint, false},
+		{"GetLinkEndpoint", &e.GetLinkEndpoint, true},
+		{"ListLinksEndpoint", &e.ListLinksEndpoint, false},
+		{"UpdateLinkEndpoint", &e.UpdateLinkEndpoint, true},
+		{"RemoveLinkEndpoint", &e.RemoveLinkEndpoint, true},
+		{"ListDomainsEndpoint", &e.ListDomainsEndpoint, false},
+		{"ListSpacesEndpoint", &e.ListSpacesEndpoint, false},
+		{"ListPixelsEndpoint", &e.ListPixelsEndpoint, false},
+		{"PublicStatsEndpoint", &e.PublicStatsEndpoint, true},
+		{"StatsEndpoint", &e.StatsEndpoint, true},
+		{"AccountEndpoint", &e.AccountEndpoint, false},
+		{"PlanEndpoint", &e.PlanEndpoint, false},
+		{"LoginEndpoint", &e.LoginEndpoint, false},
+		{"RefreshTokenEndpoint", &e.RefreshTokenEndpoint, false},
+	}
+}
+
+// WithEndpoints sets the endpoints of the client, e.g. for an instance with another layout.
+// The empty fields of endpoints keep the endpoint set before.
+func WithEndpoints(endpoints Endpoints) func(*GoZaya) {
+	return func(g *GoZaya) {
+		current := g.endpointSpecs()
+		for i, spec := range endpoints.specs() {
+			if *spec.value != "" {
+				*current[i].value = *spec.value
+			}
+		}
+	}
+}
+
+// WithEndpointOverride sets the endpoint of an operation, e.g. "api/v1/links/{id}/details"
+// for OperationGetLink. It fails, and then every call, for the operations without an
+// endpoint of their own, such as OperationRaw.
+func WithEndpointOverride(operation Operation, endpoint string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		name, ok := operationEndpoints[operation]
+		if !ok {
+			g.optionFailed("WithEndpointOverride", fmt.Errorf("operation %q has no endpoint of its own", operation))
+			return
+		}
+		for _, spec := range g.endpointSpecs() {
+			if spec.name == name {
+				*spec.value = endpoint
+			}
+		}
+	}
+}
+
+// checkConfig validates the endpoints of the client, recording the first invalid one as the
+// construction error of the client.
+func (g *GoZaya) checkConfig() {
+	if _, ok := g.initErr.(*EndpointError); ok {
+		g.initErr = nil
+	}
+	if g.initErr != nil {
+		return
+	}
+	for _, spec := range g.endpointSpecs() {
+		if err := validateEndpoint(spec.name, *spec.value, spec.takeID); err != nil {
+			g.initErr = err
+			return
+		}
+	}
+}
+
+// validateEndpoint checks that an endpoint is a non-empty path relative to the base path,
+// whose only placeholder is {id} on the endpoints taking an ID.
+func validateEndpoint(name string, endpoint string, takeID bool) error {
+	invalid := func(reason string) error {
+		return &EndpointError{Endpoint: name, Value: endpoint, Reason: reason}
+	}
+	switch {
+	case strings.TrimSpace(endpoint) == "":
+		return invalid("required endpoint is empty")
+	case strings.Contains(endpoint, "://"):
+		return invalid("must be a path relative to the base path, not a URL")
+	case strings.HasPrefix(endpoint, urlSeparator):
+		return invalid("must be relative to the base path, without a leading slash")
+	case strings.ContainsAny(endpoint, "?#"):
+		return invalid("must not hold a query or a fragment")
+	}
+
+	rest := endpoint
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			if strings.IndexByte(rest, '}') >= 0 {
+				return invalid("unbalanced braces")
+			}
+			return nil
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return invalid("unterminated placeholder")
+		}
+		placeholder := rest[start : start+end+1]
+		if placeholder != idPlaceholder || !takeID {
+			return invalid(fmt.Sprintf("unknown placeholder %s", placeholder))
+		}
+		rest = rest[start+end+1:]
+	}
+}
+
+// endpointPath returns the path of a call to an endpoint, with the ID, if any, escaped in
+// place of {id} or appended to the endpoint.
+func endpointPath(name string, endpoint string, id string) (string, error) {
+	takeID := id != ""
+	if err := validateEndpoint(name, endpoint, takeID || strings.Contains(endpoint, idPlaceholder)); err != nil {
+		return "", err
+	}
+	if !takeID {
+		if strings.Contains(endpoint, idPlaceholder) {
+			return "", &EndpointError{Endpoint: name, Value: endpoint, Reason: "missing ID for placeholder {id}"}
+		}
+		return endpoint, nil
+	}
+	if strings.Contains(endpoint, idPlaceholder) {
+		return strings.ReplaceAll(endpoint, idPlaceholder, escapePathSegment(id)), nil
+	}
+	return endpoint + urlSeparator + escapePathSegment(id), nil
+}
+
+// escapePathSegment escapes a value, e.g. an ID or an alias, as a single path segment, so
+// that slashes, question marks and unicode do not change the path. The "." and ".." values
+// are escaped too, as they would otherwise be removed with the segment before them.
+func escapePathSegment(value string) string {
+	if value == "." || value == ".." {
+		return strings.ReplaceAll(value, ".", "%2E")
+	}
+	return url.PathEscape(value)
+}
+
+// joinURL joins a path to a base path with url.JoinPath, so that the slashes between them
+// are not doubled. The query of path, if any, is kept as is.
+func joinURL(base string, path string) string {
+	path, query, hasQuery := strings.Cut(path, "?")
+	joined, err := url.JoinPath(base, path)
+	if err != nil {
+		joined = strings.TrimRight(base, urlSeparator) + urlSeparator + strings.TrimLeft(path, urlSeparator)
+	}
+	if hasQuery {
+		joined += "?" + query
+	}
+	return joined
+}
@@ -0,0 +1,108 @@
+package gozaya
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by NewClientFromEnv
+const (
+	EnvVarBaseURL = "ZAYA_BASE_URL"
+	EnvVarToken   = "ZAYA_TOKEN"
+	EnvVarTimeout = "ZAYA_TIMEOUT"
+	EnvVarDebug   = "ZAYA_DEBUG"
+)
+
+// DefaultBaseURL is the base URL of the hosted Zaya API, used by NewClientFromEnv when
+// ZAYA_BASE_URL is not set
+const DefaultBaseURL = "https://zaya.io"
+
+// EnvError is returned by NewClientFromEnv when an environment variable is invalid
+type EnvError struct {
+	Name   string
+	Value  string
+	Reason string
+}
+
+// Error stringifies the EnvError
+func (e *EnvError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %s", e.Name, e.Value, e.Reason)
+}
+
+// NewClientFromEnv creates a client configured from the environment:
+//
+//   - ZAYA_BASE_URL, the base URL of the API, DefaultBaseURL when not set
+//   - ZAYA_TOKEN, the token of the calls made without an explicit token, read once
+//   - ZAYA_TIMEOUT, the timeout of each attempt, as a duration like "10s" or a number of seconds
+//   - ZAYA_DEBUG, a boolean dumping the requests and responses to stderr, see WithDebug
+//
+// The options are applied after the environment and take precedence over it. The returned
+// error joins the errors of all the invalid variables, along with the configuration error of
+// the client.
+func NewClientFromEnv(options ...Option) (*GoZaya, error) {
+	var (
+		envOptions []Option
+		errs       []error
+	)
+
+	basePath := DefaultBaseURL
+	if value, ok := lookupEnv(EnvVarBaseURL); ok {
+		basePath = value
+	}
+	if value, ok := lookupEnv(EnvVarToken); ok {
+		envOptions = append(envOptions, WithToken(value))
+	}
+	if value, ok := lookupEnv(EnvVarTimeout); ok {
+		timeout, err := parseEnvDuration(value)
+		if err != nil {
+			errs = append(errs, &EnvError{Name: EnvVarTimeout, Value: value, Reason: err.Error()})
+		} else {
+			envOptions = append(envOptions, WithTimeout(timeout))
+		}
+	}
+	if value, ok := lookupEnv(EnvVarDebug); ok {
+		debug, err := strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, &EnvError{Name: EnvVarDebug, Value: value, Reason: "not a boolean"})
+		} else if debug {
+			envOptions = append(envOptions, WithDebug(os.Stderr))
+		}
+	}
+
+	client := NewClient(basePath, append(envOptions, options...)...)
+	if err := client.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return client, nil
+}
+
+// lookupEnv returns the trimmed value of an environment variable, and false when it is not set or blank
+func lookupEnv(name string) (string, bool) {
+	value := strings.TrimSpace(os.Getenv(name))
+	return value, value != ""
+}
+
+// parseEnvDuration parses a duration like "10s", or a number of seconds
+func parseEnvDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds < 0 {
+			return 0, errors.New("negative duration")
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.New("not a duration like 10s or a number of seconds")
+	}
+	if duration < 0 {
+		return 0, errors.New("negative duration")
+	}
+	return duration, nil
+}
@@ -0,0 +1,55 @@
+package gozaya
+
+import (
+	"html"
+	"mime"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxErrorSnippetLength bounds the length of the body quoted in the message of an APIError.
+const maxErrorSnippetLength = 200
+
+var (
+	htmlTitle   = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlHidden  = regexp.MustCompile(`(?is)<(script|style|head)[^>]*>.*?</(script|style|head)>`)
+	htmlTag     = regexp.MustCompile(`(?s)<[^>]*>`)
+	whitespaces = regexp.MustCompile(`\s+`)
+)
+
+// errorSnippet returns a short, single line excerpt of an error body that could not be
+// parsed as an API error, e.g. the HTML page of a proxy or of a maintenance. For HTML, it is
+// the title of the page, or its text when it has no title. Empty when the body is empty.
+func errorSnippet(contentType string, body []byte) string {
+	text := string(body)
+	if isHTML(contentType, text) {
+		if title := htmlTitle.FindStringSubmatch(text); title != nil && strings.TrimSpace(title[1]) != "" {
+			text = title[1]
+		} else {
+			text = htmlTag.ReplaceAllString(htmlHidden.ReplaceAllString(text, " "), " ")
+		}
+		text = html.UnescapeString(text)
+	}
+	text = strings.TrimSpace(whitespaces.ReplaceAllString(text, " "))
+	return truncate(strings.ToValidUTF8(text, ""), maxErrorSnippetLength)
+}
+
+// isHTML reports whether an error body is an HTML page, from its content type or from its
+// content, as proxies do not always label their pages.
+func isHTML(contentType string, body string) bool {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && (mediaType == "text/html" || mediaType == "application/xhtml+xml") {
+		return true
+	}
+	start := strings.ToLower(strings.TrimSpace(body))
+	return strings.HasPrefix(start, "<!doctype html") || strings.HasPrefix(start, "<html")
+}
+
+// truncate shortens s to at most n runes, marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:n-1])) + "…"
+}
@@ -1,7 +1,12 @@
 package gozaya
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
 	"strings"
+	"time"
 )
 
 // HTTPErrorResponse is a model of an error response
@@ -36,3 +41,199 @@ func (e HTTPErrorResponse) String() string {
 func (e HTTPErrorResponse) NotEmpty() bool {
 	return len(e.Error) > 0 || len(e.Message) > 0 || len(e.Description) > 0
 }
+
+// RateLimitError is returned when the API rejected a request with 429 Too Many Requests.
+// It wraps the *APIError of the response, whose RetryAfter also accounts for X-RateLimit-Reset.
+type RateLimitError struct {
+	*APIError
+	// Reset is when the rate limit window ends, zero when the API did not tell
+	Reset time.Time
+	// RateLimit is the rate limit reported with the response
+	RateLimit RateLimit
+}
+
+// Error stringifies the RateLimitError
+func (e *RateLimitError) Error() string {
+	return e.APIError.Error()
+}
+
+// Unwrap allows matching the error with *APIError
+func (e *RateLimitError) Unwrap() error {
+	return e.APIError
+}
+
+// newRateLimitError builds the error of a 429 response from its rate limit headers.
+func newRateLimitError(apiErr *APIError, limit RateLimit, now time.Time) *RateLimitError {
+	if apiErr.RetryAfter <= 0 && limit.Reset.After(now) {
+		apiErr.RetryAfter = limit.Reset.Sub(now)
+	}
+	reset := limit.Reset
+	if reset.IsZero() && apiErr.RetryAfter > 0 {
+		reset = now.Add(apiErr.RetryAfter)
+	}
+	return &RateLimitError{APIError: apiErr, Reset: reset, RateLimit: limit}
+}
+
+// ValidationError is returned when the API rejected the fields of a request, with 422
+// Unprocessable Entity or 400 Bad Request. It wraps the *APIError of the response and matches
+// ErrValidation whatever its status.
+type ValidationError struct {
+	*APIError
+	// FieldErrors maps the rejected fields to their messages, e.g. "alias" to
+	// ["The alias has already been taken."]
+	FieldErrors map[string][]string
+}
+
+// Error stringifies the ValidationError
+func (e *ValidationError) Error() string {
+	return e.APIError.Error()
+}
+
+// Unwrap allows matching the error with *APIError
+func (e *ValidationError) Unwrap() error {
+	return e.APIError
+}
+
+// Is allows matching the error with ErrValidation, including for a 400 response
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Field returns the messages of a rejected field, nil when the field was accepted
+func (e *ValidationError) Field(name string) []string {
+	return e.FieldErrors[name]
+}
+
+// Fields returns the sorted names of the rejected fields
+func (e *ValidationError) Fields() []string {
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// validationBody is a Laravel style validation error body
+type validationBody struct {
+	Message string              `json:"message"`
+	Errors  map[string][]string `json:"errors"`
+}
+
+// newValidationError builds the error of a response rejecting fields, nil when the body does
+// not list field errors.
+func newValidationError(apiErr *APIError, status string, body []byte) *ValidationError {
+	var parsed validationBody
+	if json.Unmarshal(body, &parsed) != nil || len(parsed.Errors) == 0 {
+		return nil
+	}
+
+	validationErr := &ValidationError{APIError: apiErr, FieldErrors: parsed.Errors}
+	messages := make([]string, 0, len(parsed.Errors))
+	for _, field := range validationErr.Fields() {
+		messages = append(messages, field+": "+strings.Join(parsed.Errors[field], " "))
+	}
+	summary := status
+	if parsed.Message != "" {
+		summary += ": " + parsed.Message
+	}
+	apiErr.Message = summary + " (" + strings.Join(messages, "; ") + ")"
+	return validationErr
+}
+
+// Sentinel errors matched with errors.Is by the *APIError of the responses with the
+// corresponding status code
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrValidation   = errors.New("validation failed")
+)
+
+// statusSentinels maps the status codes to their sentinel errors
+var statusSentinels = map[int]error{
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusConflict:            ErrConflict,
+	http.StatusTooManyRequests:     ErrRateLimited,
+	http.StatusUnprocessableEntity: ErrValidation,
+}
+
+// Is allows matching the error with the sentinel error of its status code, e.g. ErrNotFound
+func (apiError *APIError) Is(target error) bool {
+	sentinel, ok := statusSentinels[apiError.Code]
+	return ok && target == sentinel
+}
+
+// IsNotFound reports whether err is caused by a 404 response
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized reports whether err is caused by a 401 response
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden reports whether err is caused by a 403 response
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsConflict reports whether err is caused by a 409 response
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsValidation reports whether err is caused by a 422 response, or a 400 response rejecting
+// fields of the request
+func IsValidation(err error) bool {
+	return errors.Is(err, ErrValidation)
+}
+
+// IsRateLimited reports whether err is caused by a 429 response
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// TemporaryError is implemented by the errors of the failed calls to the API, to classify them
+// without depending on the error types, e.g. in the retry framework of a job queue. The delay
+// is named RetryDelay as RetryAfter is a field of *APIError; see also IsTemporary and RetryAfter.
+type TemporaryError interface {
+	error
+	// Temporary reports whether the call may succeed if made again as is
+	Temporary() bool
+	// RetryDelay is the delay requested by the API before retrying, 0 when not requested
+	RetryDelay() time.Duration
+}
+
+// Temporary reports whether the request may succeed if sent again, see IsRetryable
+func (apiError *APIError) Temporary() bool {
+	return apiError.IsRetryable()
+}
+
+// RetryDelay returns the delay requested by the API before retrying, 0 when not requested
+func (apiError *APIError) RetryDelay() time.Duration {
+	return apiError.RetryAfter
+}
+
+// IsTemporary reports whether the call that failed with err may succeed if made again, e.g. on
+// timeouts, connection failures, 429 and 503 responses. The errors not telling, such as the
+// policy and validation errors raised by the client, are permanent.
+func IsTemporary(err error) bool {
+	var temporary interface{ Temporary() bool }
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
+
+// RetryAfter returns the delay requested by the API before retrying the call that failed with
+// err, 0 when not requested.
+func RetryAfter(err error) time.Duration {
+	var delayed interface{ RetryDelay() time.Duration }
+	if errors.As(err, &delayed) {
+		return delayed.RetryDelay()
+	}
+	return 0
+}
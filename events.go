@@ -0,0 +1,112 @@
+package gozaya
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Events receives the lifecycle events of the calls to the API, e.g. to plug the telemetry
+// of a framework. The methods are called before the call goes on and must not block. Embed
+// NopEvents to implement only some of them.
+type Events interface {
+	// OnRequestStart is called when a call starts, before its first attempt
+	OnRequestStart(ctx context.Context, info *RequestStartInfo)
+	// OnRetry is called before a request is sent again
+	OnRetry(ctx context.Context, info *RetryInfo)
+	// OnSuccess is called when a call succeeds
+	OnSuccess(ctx context.Context, meta *CallMeta)
+	// OnFailure is called when a call fails, its retries exhausted
+	OnFailure(ctx context.Context, meta *CallMeta)
+}
+
+// NopEvents ignores all the events, to embed in partial implementations of Events
+type NopEvents struct{}
+
+// OnRequestStart does nothing
+func (NopEvents) OnRequestStart(context.Context, *RequestStartInfo) {}
+
+// OnRetry does nothing
+func (NopEvents) OnRetry(context.Context, *RetryInfo) {}
+
+// OnSuccess does nothing
+func (NopEvents) OnSuccess(context.Context, *CallMeta) {}
+
+// OnFailure does nothing
+func (NopEvents) OnFailure(context.Context, *CallMeta) {}
+
+// RequestStartInfo describes a call about to be made
+type RequestStartInfo struct {
+	Operation Operation
+	Method    string
+	// Path is the path of the endpoint relative to the base path
+	Path          string
+	Start         time.Time
+	InstanceID    string
+	CorrelationID string
+}
+
+// RetryInfo describes a request about to be sent again
+type RetryInfo struct {
+	Operation Operation
+	Method    string
+	// Reason is why the request is sent again: "failover", "retry" or "rate limited"
+	Reason string
+	// Attempt is the number of the attempt about to be made, 2 for the first retry
+	Attempt int
+	// Delay is the wait before the attempt
+	Delay time.Duration
+	// Elapsed is the time since the start of the call
+	Elapsed time.Duration
+	// StatusCode is the status of the failed attempt, 0 when no response was received
+	StatusCode int
+	// Err is the error of the failed attempt, nil when it received an error response
+	Err error
+}
+
+// WithEvents passes the lifecycle events of the calls to events
+func WithEvents(events Events) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.events = events })
+	}
+}
+
+// reportStart logs the start of a call and passes it to the events.
+func (g *GoZaya) reportStart(ctx context.Context, tuning *tuning, req *resty.Request, method string, path string, start time.Time) {
+	g.logRequest(ctx, tuning, req, method, path)
+	if tuning.events == nil {
+		return
+	}
+	tuning.events.OnRequestStart(ctx, &RequestStartInfo{
+		Operation:     OperationFromContext(ctx),
+		Method:        method,
+		Path:          path,
+		Start:         start,
+		InstanceID:    g.instanceID,
+		CorrelationID: CorrelationIDFromContext(ctx),
+	})
+}
+
+// reportRetry logs a request about to be sent again and passes it to the events.
+func (g *GoZaya) reportRetry(ctx context.Context, tuning *tuning, req *resty.Request, reason string, attempt int, start time.Time, delay time.Duration, resp *resty.Response, err error) {
+	g.logRetry(ctx, tuning, req, reason, attempt, delay, resp, err)
+	if tuning.events == nil {
+		return
+	}
+	info := &RetryInfo{
+		Operation: OperationFromContext(ctx),
+		Method:    req.Method,
+		Reason:    reason,
+		Attempt:   attempt,
+		Delay:     delay,
+		Elapsed:   time.Since(start),
+	}
+	if resp != nil && resp.RawResponse != nil {
+		info.StatusCode = resp.StatusCode()
+	}
+	if err != nil {
+		info.Err = g.redactError(checkForError(resp, err, "failed attempt"), req)
+	}
+	tuning.events.OnRetry(ctx, info)
+}
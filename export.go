@@ -0,0 +1,152 @@
+package gozaya
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	// exportDefaultPartitions is the number of partitions delivered concurrently by default.
+	exportDefaultPartitions = 4
+	// exportSortOrder lists the oldest links first so that links created during an export
+	// land on the last pages instead of shifting the pages behind the cursor.
+	exportSortOrder = "asc"
+)
+
+// ExportOptions configures ExportLinks
+type ExportOptions struct {
+	// Params filters the exported links. Page, PerPage and Sort are managed by the export.
+	Params GetLinksParams
+	// Partitions is the number of partitions the links are sharded into by ID, delivered
+	// concurrently. It defaults to 4.
+	Partitions int
+	// PageSize is the number of links fetched per request. It defaults to the API maximum.
+	PageSize int
+}
+
+// ExportLinks exports all the links matching options.Params by walking the pages in order
+// and sharding the links by ID into partitions delivered concurrently.
+// The pages are walked with a cursor, stepping back when links are deleted before it so that
+// the shifted links are fetched again, and the links are deduplicated by ID.
+// sink is called with the links of a partition and must be safe for concurrent use;
+// links of a partition are delivered in order. The export stops at the first error.
+func (g *GoZaya) ExportLinks(ctx context.Context, token string, options ExportOptions, sink func(ctx context.Context, partition int, links []*Link) error) error {
+	if options.Partitions <= 0 {
+		options.Partitions = exportDefaultPartitions
+	}
+	if options.PageSize <= 0 {
+		options.PageSize = listAllPageSize
+	}
+
+	params := options.Params
+	params.PerPage = IntP(options.PageSize)
+	params.Sort = StringP(exportSortOrder)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	partitions := make([]chan []*Link, options.Partitions)
+	for partition := range partitions {
+		partitions[partition] = make(chan []*Link, 1)
+		wg.Add(1)
+		go func(partition int, links <-chan []*Link) {
+			defer wg.Done()
+			for links := range links {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := sink(ctx, partition, links); err != nil {
+					fail(err)
+				}
+			}
+		}(partition, partitions[partition])
+	}
+
+	err := g.walkExport(ctx, token, params, func(links []*Link) error {
+		shards := make([][]*Link, len(partitions))
+		for _, link := range links {
+			shard := exportShard(link.ID, len(partitions))
+			shards[shard] = append(shards[shard], link)
+		}
+		for partition, shard := range shards {
+			if len(shard) == 0 {
+				continue
+			}
+			select {
+			case partitions[partition] <- shard:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+	for _, links := range partitions {
+		close(links)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return err
+}
+
+// walkExport walks the pages of links matching params in order and calls deliver with the
+// links not seen before. When the total drops, links were deleted before the cursor and the
+// following ones shifted back, so the cursor steps back by as many pages.
+func (g *GoZaya) walkExport(ctx context.Context, token string, params GetLinksParams, deliver func(links []*Link) error) error {
+	pageSize := *params.PerPage
+	seen := make(map[int64]struct{})
+	total := -1
+	for page := 1; ; {
+		params := params
+		params.Page = IntP(page)
+		links, meta, err := g.ListLinks(ctx, token, params)
+		if err != nil {
+			return err
+		}
+
+		fresh := make([]*Link, 0, len(links))
+		for _, link := range links {
+			if _, ok := seen[link.ID]; ok {
+				continue
+			}
+			seen[link.ID] = struct{}{}
+			fresh = append(fresh, link)
+		}
+		if len(fresh) > 0 {
+			if err := deliver(fresh); err != nil {
+				return err
+			}
+		}
+
+		next := page + 1
+		if meta != nil {
+			if total >= 0 && meta.Total < total {
+				next = max(page-(total-meta.Total+pageSize-1)/pageSize, 1)
+			}
+			total = meta.Total
+		}
+		if next > page && (len(links) == 0 || !meta.HasNext()) {
+			return nil
+		}
+		page = next
+	}
+}
+
+// exportShard returns the partition of the link with the given ID among n partitions
+func exportShard(id int64, n int) int {
+	return int(uint64(id) % uint64(n))
+}
@@ -0,0 +1,119 @@
+package gozaya
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// defaultFailoverCooldown is how long a failing base URL is avoided by default.
+const defaultFailoverCooldown = 30 * time.Second
+
+// failover routes the requests to the first healthy base URL among the primary one and
+// the fallbacks
+type failover struct {
+	fallbacks []string
+	cooldown  time.Duration
+
+	mu sync.Mutex
+	// downUntil is when the base URLs that failed are tried again
+	downUntil map[string]time.Time
+}
+
+// WithFailover sets fallback base URLs, e.g. a mirror hostname, used in order when the
+// primary base URL fails with a network error or a 502, 503 or 504 response. A failing
+// base URL is avoided for cooldown, 30 seconds when 0, then tried again, so that the
+// primary base URL is used again once it recovered. Idempotent requests failing on one base
+// URL are sent again right away to the next one; other requests fail and the following
+// requests use the next base URL. It fails, and then every call, when a fallback is not a valid
+// base URL.
+func WithFailover(cooldown time.Duration, fallbacks ...string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if len(fallbacks) == 0 {
+			g.failover = nil
+			return
+		}
+		if cooldown <= 0 {
+			cooldown = defaultFailoverCooldown
+		}
+		f := &failover{cooldown: cooldown, downUntil: make(map[string]time.Time)}
+		for _, fallback := range fallbacks {
+			normalized, err := normalizeBasePath(fallback)
+			if err != nil {
+				g.optionFailed("WithFailover", err)
+				return
+			}
+			f.fallbacks = append(f.fallbacks, normalized)
+		}
+		g.failover = f
+	}
+}
+
+// pick returns the base URL of the next attempt: the first healthy one, or the one
+// recovering first when all of them are failing.
+func (f *failover) pick(primary string) string {
+	if f == nil {
+		return primary
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	best, bestUntil := primary, time.Time{}
+	for i, base := range f.candidates(primary) {
+		until, down := f.downUntil[base]
+		if !down || !now.Before(until) {
+			return base
+		}
+		if i == 0 || until.Before(bestUntil) {
+			best, bestUntil = base, until
+		}
+	}
+	return best
+}
+
+// report records the outcome of an attempt on a base URL made with ctx. It returns whether
+// the attempt failed and another healthy base URL is available. An attempt ended by ctx
+// tells nothing about the base URL and is not recorded.
+func (f *failover) report(ctx context.Context, primary, base string, resp *resty.Response, sent bool, err error) bool {
+	if f == nil || !sent || ctx.Err() != nil {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !hostDown(ctx, resp, err) {
+		delete(f.downUntil, base)
+		return false
+	}
+	now := time.Now()
+	f.downUntil[base] = now.Add(f.cooldown)
+
+	for _, candidate := range f.candidates(primary) {
+		if until, down := f.downUntil[candidate]; candidate != base && (!down || !now.Before(until)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostDown reports whether an attempt failed in a way telling that its base URL is unavailable,
+// including the DNS and TLS failures that are not worth retrying against the same base URL.
+// A failure caused by ctx being done, e.g. the deadline of the caller, is not.
+func hostDown(ctx context.Context, resp *resty.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return transientFailure(resp, nil)
+}
+
+// candidates returns the base URLs in order of preference.
+func (f *failover) candidates(primary string) []string {
+	return append([]string{primary}, f.fallbacks...)
+}
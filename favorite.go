@@ -0,0 +1,21 @@
+package gozaya
+
+import (
+	"context"
+)
+
+// StarLink marks the link with the given ID as a favorite of the team
+func (g *GoZaya) StarLink(ctx context.Context, token string, id string) (*Link, error) {
+	return g.UpdateLink(ctx, token, id, &GenerateLinkRequest{Favorite: IntP(1)})
+}
+
+// UnstarLink removes the link with the given ID from the favorites
+func (g *GoZaya) UnstarLink(ctx context.Context, token string, id string) (*Link, error) {
+	return g.UpdateLink(ctx, token, id, &GenerateLinkRequest{Favorite: IntP(0)})
+}
+
+// ListStarredLinks returns a page of the favorite links matching params
+func (g *GoZaya) ListStarredLinks(ctx context.Context, token string, params GetLinksParams) ([]*Link, *Page, error) {
+	params.Favorite = IntP(1)
+	return g.ListLinks(ctx, token, params)
+}
@@ -0,0 +1,53 @@
+// Package fixtures exports sanitized Zaya API payloads for every model decoded by gozaya,
+// so that code using the client can be tested against realistic responses.
+package fixtures
+
+import (
+	"embed"
+	"io/fs"
+	"sort"
+)
+
+// Names of the available payloads
+const (
+	GetLink         = "get_link.json"
+	CreateLink      = "create_link.json"
+	ListLinks       = "list_links.json"
+	RemoveLink      = "remove_link.json"
+	ListDomains     = "list_domains.json"
+	ListSpaces      = "list_spaces.json"
+	ListPixels      = "list_pixels.json"
+	ErrorValidation = "error_validation.json"
+	ErrorNotFound   = "error_not_found.json"
+)
+
+//go:embed payloads/*.json
+var payloads embed.FS
+
+// Load returns the payload with the given name
+func Load(name string) ([]byte, error) {
+	return payloads.ReadFile("payloads/" + name)
+}
+
+// MustLoad returns the payload with the given name and panics if it does not exist
+func MustLoad(name string) []byte {
+	payload, err := Load(name)
+	if err != nil {
+		panic(err)
+	}
+	return payload
+}
+
+// Names returns the names of all the available payloads
+func Names() []string {
+	entries, err := fs.ReadDir(payloads, "payloads")
+	if err != nil {
+		panic(err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
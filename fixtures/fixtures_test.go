@@ -0,0 +1,289 @@
+package fixtures_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	gozaya "github.com/erfandiakoo/go-zaya"
+	"github.com/erfandiakoo/go-zaya/fixtures"
+)
+
+// golden describes how a payload is decoded by the client and what it decodes to
+type golden struct {
+	status int
+	// call calls the client method decoding the payload
+	call func(ctx context.Context, client *gozaya.GoZaya) (interface{}, error)
+	// check asserts the decoded value, or the returned error for the error payloads
+	check func(t *testing.T, got interface{}, err error)
+}
+
+var goldens = map[string]golden{
+	fixtures.GetLink: {
+		status: http.StatusOK,
+		call: func(ctx context.Context, client *gozaya.GoZaya) (interface{}, error) {
+			return client.GetLink(ctx, "token", "1042")
+		},
+		check: func(t *testing.T, got interface{}, err error) {
+			link := mustLink(t, got, err)
+			assertEqual(t, "ID", link.ID, int64(1042))
+			assertEqual(t, "UserID", link.UserID, int64(7))
+			assertEqual(t, "SpaceID", link.SpaceID, (*int64)(nil))
+			assertEqual(t, "Domain", link.Domain, "https://go.example.com")
+			assertEqual(t, "Alias", link.Alias, "spring-sale")
+			assertEqual(t, "LongURL", link.LongURL, "https://www.example.com/campaigns/spring?utm_source=zaya")
+			assertEqual(t, "ShortURL", link.ShortURL, "https://go.example.com/spring-sale")
+			assertEqual(t, "Title", link.Title, "Spring sale")
+			assertEqual(t, "Status", link.Status, int64(1))
+			assertEqual(t, "Public", link.Public, true)
+			assertEqual(t, "HasPassword", link.HasPassword, false)
+			assertEqual(t, "Disabled", link.Disabled, false)
+			assertEqual(t, "StatsPrivacy", link.StatsPrivacy, gozaya.StatsPublic)
+			assertEqual(t, "Clicks", link.Clicks, int64(1337))
+			assertEqual(t, "ExpirationURL", link.ExpirationURL, "https://www.example.com/campaigns")
+			assertEqual(t, "ExpirationClicks", link.ExpirationClicks, gozaya.Int64P(5000))
+			assertEqual(t, "ExpiresAt", link.ExpiresAt, timeP(date(2025, 6, 30, 23, 59, 59)))
+			assertEqual(t, "CreatedAt", link.CreatedAt, date(2025, 3, 1, 9, 30, 0))
+			assertEqual(t, "UpdatedAt", link.UpdatedAt, date(2025, 3, 2, 11, 45, 12))
+		},
+	},
+	fixtures.CreateLink: {
+		status: http.StatusCreated,
+		call: func(ctx context.Context, client *gozaya.GoZaya) (interface{}, error) {
+			return client.CreateLink(ctx, "token", &gozaya.GenerateLinkRequest{Url: "https://www.example.com/docs"})
+		},
+		check: func(t *testing.T, got interface{}, err error) {
+			link := mustLink(t, got, err)
+			assertEqual(t, "ID", link.ID, int64(1043))
+			assertEqual(t, "SpaceID", link.SpaceID, gozaya.Int64P(12))
+			assertEqual(t, "Domain", link.Domain, "https://zaya.io")
+			assertEqual(t, "Alias", link.Alias, "Qx7pLm")
+			assertEqual(t, "ShortURL", link.ShortURL, "https://zaya.io/Qx7pLm")
+			assertEqual(t, "HasPassword", link.HasPassword, true)
+			assertEqual(t, "StatsPrivacy", link.StatsPrivacy, gozaya.StatsPrivate)
+			assertEqual(t, "ExpirationURL", link.ExpirationURL, "")
+			assertEqual(t, "ExpirationClicks", link.ExpirationClicks, (*int64)(nil))
+			assertEqual(t, "ExpiresAt", link.ExpiresAt, (*time.Time)(nil))
+			assertEqual(t, "CreatedAt", link.CreatedAt, date(2025, 3, 5, 14, 0, 0))
+		},
+	},
+	fixtures.ListLinks: {
+		status: http.StatusOK,
+		call: func(ctx context.Context, client *gozaya.GoZaya) (interface{}, error) {
+			links, page, err := client.ListLinks(ctx, "token", gozaya.GetLinksParams{})
+			return listResult[*gozaya.Link]{links, page}, err
+		},
+		check: func(t *testing.T, got interface{}, err error) {
+			result := mustList[*gozaya.Link](t, got, err, 2)
+			assertPage(t, result.page, gozaya.Page{Total: 5, PerPage: 2, CurrentPage: 1, LastPage: 3, NextPageURL: "https://zaya.io/api/v1/links?page=2"})
+			first, second := result.items[0], result.items[1]
+			assertEqual(t, "[0].ID", first.ID, int64(1042))
+			assertEqual(t, "[0].SpaceID", first.SpaceID, (*int64)(nil))
+			assertEqual(t, "[0].Clicks", first.Clicks, int64(1337))
+			assertEqual(t, "[1].ID", second.ID, int64(1041))
+			assertEqual(t, "[1].SpaceID", second.SpaceID, gozaya.Int64P(12))
+			assertEqual(t, "[1].Disabled", second.Disabled, true)
+			assertEqual(t, "[1].StatsPrivacy", second.StatsPrivacy, gozaya.StatsPasswordProtected)
+			assertEqual(t, "[1].Clicks", second.Clicks, int64(87))
+			if len(second.GeoTarget) == 0 {
+				t.Error("[1].GeoTarget is empty, want the raw geo targets")
+			}
+		},
+	},
+	fixtures.RemoveLink: {
+		status: http.StatusOK,
+		call: func(ctx context.Context, client *gozaya.GoZaya) (interface{}, error) {
+			return client.RemoveLink(ctx, "token", "1042")
+		},
+		check: func(t *testing.T, got interface{}, err error) {
+			if err != nil {
+				t.Fatalf("RemoveLink() error = %v", err)
+			}
+			assertEqual(t, "RemoveLinkResponse", *got.(*gozaya.RemoveLinkResponse), gozaya.RemoveLinkResponse{ID: 1042, Object: "link", Deleted: true, Status: 200})
+		},
+	},
+	fixtures.ListDomains: {
+		status: http.StatusOK,
+		call: func(ctx context.Context, client *gozaya.GoZaya) (interface{}, error) {
+			domains, page, err := client.ListDomains(ctx, "token", gozaya.ListParams{})
+			return listResult[*gozaya.Domain]{domains, page}, err
+		},
+		check: func(t *testing.T, got interface{}, err error) {
+			result := mustList[*gozaya.Domain](t, got, err, 1)
+			assertPage(t, result.page, gozaya.Page{Total: 1, PerPage: 10, CurrentPage: 1, LastPage: 1})
+			domain := result.items[0]
+			assertEqual(t, "ID", domain.ID, int64(3))
+			assertEqual(t, "Name", domain.Name, "go.example.com")
+			assertEqual(t, "URL", domain.URL, "https://go.example.com")
+			assertEqual(t, "IndexPage", domain.IndexPage, "https://www.example.com")
+			assertEqual(t, "NotFoundPage", domain.NotFoundPage, "https://www.example.com/404")
+			assertEqual(t, "CreatedAt", domain.CreatedAt.UTC(), date(2024, 11, 2, 10, 0, 0))
+		},
+	},
+	fixtures.ListSpaces: {
+		status: http.StatusOK,
+		call: func(ctx context.Context, client *gozaya.GoZaya) (interface{}, error) {
+			spaces, page, err := client.ListSpaces(ctx, "token", gozaya.ListParams{})
+			return listResult[*gozaya.Space]{spaces, page}, err
+		},
+		check: func(t *testing.T, got interface{}, err error) {
+			result := mustList[*gozaya.Space](t, got, err, 1)
+			assertPage(t, result.page, gozaya.Page{Total: 1, PerPage: 10, CurrentPage: 1, LastPage: 1})
+			space := result.items[0]
+			assertEqual(t, "ID", space.ID, int64(12))
+			assertEqual(t, "Name", space.Name, "Campaigns")
+			assertEqual(t, "Color", space.Color, int64(3))
+			assertEqual(t, "UpdatedAt", space.UpdatedAt.UTC(), date(2025, 1, 15, 16, 20, 0))
+		},
+	},
+	fixtures.ListPixels: {
+		status: http.StatusOK,
+		call: func(ctx context.Context, client *gozaya.GoZaya) (interface{}, error) {
+			pixels, page, err := client.ListPixels(ctx, "token", gozaya.ListParams{})
+			return listResult[*gozaya.Pixel]{pixels, page}, err
+		},
+		check: func(t *testing.T, got interface{}, err error) {
+			result := mustList[*gozaya.Pixel](t, got, err, 1)
+			assertPage(t, result.page, gozaya.Page{Total: 1, PerPage: 10, CurrentPage: 1, LastPage: 1})
+			pixel := result.items[0]
+			assertEqual(t, "ID", pixel.ID, int64(5))
+			assertEqual(t, "Name", pixel.Name, "Retargeting")
+			assertEqual(t, "Type", pixel.Type, "facebook")
+			assertEqual(t, "Value", pixel.Value, "123456789012345")
+			assertEqual(t, "CreatedAt", pixel.CreatedAt.UTC(), date(2024, 12, 10, 10, 0, 0))
+		},
+	},
+	fixtures.ErrorValidation: {
+		status: http.StatusUnprocessableEntity,
+		call: func(ctx context.Context, client *gozaya.GoZaya) (interface{}, error) {
+			return client.CreateLink(ctx, "token", &gozaya.GenerateLinkRequest{Url: "not a url", Alias: "docs"})
+		},
+		check: func(t *testing.T, _ interface{}, err error) {
+			var validationErr *gozaya.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("CreateLink() error = %v, want a *ValidationError", err)
+			}
+			assertEqual(t, "Code", validationErr.Code, http.StatusUnprocessableEntity)
+			assertEqual(t, "FieldErrors", validationErr.FieldErrors, map[string][]string{
+				"alias": {"The alias has already been taken."},
+				"url":   {"The url format is invalid."},
+			})
+		},
+	},
+	fixtures.ErrorNotFound: {
+		status: http.StatusNotFound,
+		call: func(ctx context.Context, client *gozaya.GoZaya) (interface{}, error) {
+			return client.GetLink(ctx, "token", "404")
+		},
+		check: func(t *testing.T, _ interface{}, err error) {
+			if !gozaya.IsNotFound(err) {
+				t.Fatalf("GetLink() error = %v, want a not found error", err)
+			}
+			var apiErr *gozaya.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("GetLink() error = %v, want an *APIError", err)
+			}
+			assertEqual(t, "Code", apiErr.Code, http.StatusNotFound)
+		},
+	},
+}
+
+func TestGoldenPayloads(t *testing.T) {
+	modes := []struct {
+		name    string
+		options []gozaya.Option
+	}{
+		{name: "lenient"},
+		{name: "strict", options: []gozaya.Option{gozaya.WithStrictDecoding()}},
+	}
+
+	for _, name := range fixtures.Names() {
+		golden, ok := goldens[name]
+		if !ok {
+			t.Errorf("payload %s has no golden test", name)
+			continue
+		}
+		for _, mode := range modes {
+			t.Run(name+"/"+mode.name, func(t *testing.T) {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(golden.status)
+					_, _ = w.Write(fixtures.MustLoad(name))
+				}))
+				defer server.Close()
+
+				client, err := gozaya.NewClientE(server.URL, mode.options...)
+				if err != nil {
+					t.Fatalf("NewClientE() error = %v", err)
+				}
+				got, err := golden.call(context.Background(), client)
+				golden.check(t, got, err)
+			})
+		}
+	}
+}
+
+type listResult[T any] struct {
+	items []T
+	page  *gozaya.Page
+}
+
+func mustLink(t *testing.T, got interface{}, err error) *gozaya.Link {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("call error = %v", err)
+	}
+	link, _ := got.(*gozaya.Link)
+	if link == nil {
+		t.Fatal("call returned no link")
+	}
+	return link
+}
+
+func mustList[T any](t *testing.T, got interface{}, err error, want int) listResult[T] {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("call error = %v", err)
+	}
+	result := got.(listResult[T])
+	if len(result.items) != want {
+		t.Fatalf("call returned %d items, want %d", len(result.items), want)
+	}
+	return result
+}
+
+func assertPage(t *testing.T, got *gozaya.Page, want gozaya.Page) {
+	t.Helper()
+	if got == nil {
+		t.Fatal("call returned no page")
+	}
+	assertEqual(t, "Page", *got, want)
+}
+
+func assertEqual(t *testing.T, field string, got, want interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s = %v, want %v", field, describe(got), describe(want))
+	}
+}
+
+// describe dereferences pointers so that the failures show values rather than addresses
+func describe(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		return v.Elem().Interface()
+	}
+	return value
+}
+
+func date(year int, month time.Month, day, hour, minute, second int) time.Time {
+	return time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+}
+
+func timeP(value time.Time) *time.Time {
+	return &value
+}
@@ -0,0 +1,236 @@
+// Package gozayatest provides helpers for testing code using the Zaya client.
+package gozayatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	gozaya "github.com/erfandiakoo/go-zaya"
+)
+
+// Call is an outgoing request recorded by a Recorder
+type Call struct {
+	Operation gozaya.Operation
+	Method    string
+	// Path is the path of the request relative to the host, e.g. "/api/v1/links/42"
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+	// Form holds the fields of form-encoded bodies
+	Form url.Values
+	// JSON holds the decoded JSON bodies
+	JSON interface{}
+	// Link is the decoded link of the create and update operations
+	Link *gozaya.GenerateLinkRequest
+}
+
+// Recorder records the requests sent by a client. The requests are still sent to the
+// underlying transport, e.g. a fake server.
+type Recorder struct {
+	next http.RoundTripper
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// NewRecorder installs a recorder on the HTTP transport of client. The clients derived
+// from client with With share the transport, and their requests are recorded as well.
+func NewRecorder(client *gozaya.GoZaya) *Recorder {
+	httpClient := client.RestyClient().GetClient()
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	recorder := &Recorder{next: next}
+	client.RestyClient().SetTransport(recorder)
+	return recorder
+}
+
+// RoundTrip records the request and sends it to the underlying transport
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	call := Call{
+		Operation: gozaya.OperationFromContext(req.Context()),
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		Query:     req.URL.Query(),
+		Header:    req.Header.Clone(),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		call.Body = body
+		decodeBody(&call, req.Header.Get("Content-Type"))
+	}
+
+	r.mu.Lock()
+	r.calls = append(r.calls, call)
+	r.mu.Unlock()
+
+	return r.next.RoundTrip(req)
+}
+
+// decodeBody decodes the form or JSON body of a call.
+func decodeBody(call *Call, contentType string) {
+	switch {
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		form, err := url.ParseQuery(string(call.Body))
+		if err != nil {
+			return
+		}
+		call.Form = form
+		if isLinkWrite(call.Operation) {
+			call.Link = linkFromForm(form)
+		}
+	case strings.Contains(contentType, "json"):
+		if err := json.Unmarshal(call.Body, &call.JSON); err != nil {
+			return
+		}
+		if isLinkWrite(call.Operation) {
+			var link gozaya.GenerateLinkRequest
+			if json.Unmarshal(call.Body, &link) == nil {
+				call.Link = &link
+			}
+		}
+	}
+}
+
+func isLinkWrite(operation gozaya.Operation) bool {
+	return operation == gozaya.OperationCreateLink || operation == gozaya.OperationUpdateLink
+}
+
+// linkFromForm decodes a link request from its form encoding.
+func linkFromForm(form url.Values) *gozaya.GenerateLinkRequest {
+	optionalInt := func(key string) *int {
+		value, err := strconv.Atoi(form.Get(key))
+		if err != nil {
+			return nil
+		}
+		return &value
+	}
+	return &gozaya.GenerateLinkRequest{
+		Url:              form.Get("url"),
+		Alias:            form.Get("alias"),
+		Password:         form.Get("password"),
+		Space:            optionalInt("space"),
+		Disable:          optionalInt("disable"),
+		Public:           optionalInt("public"),
+		Description:      form.Get("description"),
+		ExpirationDate:   form.Get("expiration_date"),
+		ExpirationTime:   form.Get("expiration_time"),
+		ExpirationClicks: optionalInt("expiration_clicks"),
+		Domain:           optionalInt("domain"),
+		ExpirationUrl:    form.Get("expiration_url"),
+		Privacy:          optionalInt("privacy"),
+		PrivacyPassword:  form.Get("privacy_password"),
+		Favorite:         optionalInt("favorite"),
+	}
+}
+
+// Calls returns the recorded calls, oldest first
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsOf returns the recorded calls of an operation, oldest first
+func (r *Recorder) CallsOf(operation gozaya.Operation) []Call {
+	var calls []Call
+	for _, call := range r.Calls() {
+		if call.Operation == operation {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// CreatedLinks returns the link requests of the recorded create operations
+func (r *Recorder) CreatedLinks() []*gozaya.GenerateLinkRequest {
+	var links []*gozaya.GenerateLinkRequest
+	for _, call := range r.CallsOf(gozaya.OperationCreateLink) {
+		if call.Link != nil {
+			links = append(links, call.Link)
+		}
+	}
+	return links
+}
+
+// Reset forgets the recorded calls
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// AssertCalled fails the test when the operation was not called
+func (r *Recorder) AssertCalled(t testing.TB, operation gozaya.Operation) {
+	t.Helper()
+	if len(r.CallsOf(operation)) == 0 {
+		t.Errorf("expected a %s call, got %s", operation, r.operations())
+	}
+}
+
+// AssertNotCalled fails the test when the operation was called
+func (r *Recorder) AssertNotCalled(t testing.TB, operation gozaya.Operation) {
+	t.Helper()
+	if calls := r.CallsOf(operation); len(calls) > 0 {
+		t.Errorf("expected no %s call, got %d", operation, len(calls))
+	}
+}
+
+// AssertCallCount fails the test when the operation was not called exactly count times
+func (r *Recorder) AssertCallCount(t testing.TB, operation gozaya.Operation, count int) {
+	t.Helper()
+	if calls := r.CallsOf(operation); len(calls) != count {
+		t.Errorf("expected %d %s calls, got %d", count, operation, len(calls))
+	}
+}
+
+// AssertCreatedLinkWithAlias fails the test when no link was created with the alias
+func (r *Recorder) AssertCreatedLinkWithAlias(t testing.TB, alias string) {
+	t.Helper()
+	var aliases []string
+	for _, link := range r.CreatedLinks() {
+		if link.Alias == alias {
+			return
+		}
+		aliases = append(aliases, strconv.Quote(link.Alias))
+	}
+	t.Errorf("expected a link created with alias %q, got aliases [%s]", alias, strings.Join(aliases, ", "))
+}
+
+// AssertCreatedLinkWithURL fails the test when no link was created for the long URL
+func (r *Recorder) AssertCreatedLinkWithURL(t testing.TB, longURL string) {
+	t.Helper()
+	var urls []string
+	for _, link := range r.CreatedLinks() {
+		if link.Url == longURL {
+			return
+		}
+		urls = append(urls, strconv.Quote(link.Url))
+	}
+	t.Errorf("expected a link created for %q, got [%s]", longURL, strings.Join(urls, ", "))
+}
+
+// operations lists the recorded operations for failure messages.
+func (r *Recorder) operations() string {
+	calls := r.Calls()
+	names := make([]string, len(calls))
+	for i, call := range calls {
+		names[i] = string(call.Operation)
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
@@ -0,0 +1,245 @@
+package gozayatest
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gozaya "github.com/erfandiakoo/go-zaya"
+	"github.com/erfandiakoo/go-zaya/fixtures"
+)
+
+// Step is a scripted response of a Server endpoint
+type Step struct {
+	// Status is the status code of the response, the status of the fixture when zero
+	Status int
+	Header http.Header
+	// Body is the body of the response, the fixture of the endpoint when nil
+	Body []byte
+	// Delay is waited before responding, or until the client gives up
+	Delay time.Duration
+	times int
+}
+
+// Times repeats the step n times
+func (s Step) Times(n int) Step {
+	s.times = n
+	return s
+}
+
+// After delays the response of the step
+func (s Step) After(delay time.Duration) Step {
+	s.Delay = delay
+	return s
+}
+
+// Succeed responds with the fixture of the endpoint
+func Succeed() Step {
+	return Step{}
+}
+
+// Delay responds with the fixture of the endpoint after delay
+func Delay(delay time.Duration) Step {
+	return Step{Delay: delay}
+}
+
+// RateLimited responds with 429 and a Retry-After header of retryAfter, rounded up to the second
+func RateLimited(retryAfter time.Duration) Step {
+	seconds := int((retryAfter + time.Second - 1) / time.Second)
+	return Step{
+		Status: http.StatusTooManyRequests,
+		Header: http.Header{"Retry-After": {strconv.Itoa(seconds)}},
+		Body:   []byte(`{"message":"Too Many Attempts.","status":429}`),
+	}
+}
+
+// MalformedJSON responds with 200 and a truncated JSON body
+func MalformedJSON() Step {
+	return Step{Status: http.StatusOK, Body: []byte(`{"data":{"id":1042,"alias":`)}
+}
+
+// Status responds with the status code and body
+func Status(status int, body []byte) Step {
+	return Step{Status: status, Body: body}
+}
+
+// route is an endpoint of a Server, its path segments in braces matching any segment
+type route struct {
+	method   string
+	segments []string
+}
+
+func parseRoute(pattern string) (route, error) {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok || method == "" || !strings.HasPrefix(path, "/") {
+		return route{}, fmt.Errorf("invalid route %q, expected e.g. \"GET /api/v1/links/{id}\"", pattern)
+	}
+	return route{method: method, segments: strings.Split(strings.Trim(path, "/"), "/")}, nil
+}
+
+func (r route) match(req *http.Request) bool {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if req.Method != r.method || len(segments) != len(r.segments) {
+		return false
+	}
+	for i, segment := range r.segments {
+		if !strings.HasPrefix(segment, "{") && segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// endpoint is a route of a Server with its default response and script
+type endpoint struct {
+	pattern string
+	route   route
+	fixture string
+	steps   []Step
+	hits    int
+}
+
+// Server is a fake Zaya API serving the fixtures payloads. The responses of an endpoint
+// can be scripted to test how code using the client handles failures:
+//
+//	server := gozayatest.NewServer()
+//	defer server.Close()
+//	server.Script("GET /api/v1/links/{id}",
+//		gozayatest.RateLimited(time.Second).Times(2),
+//		gozayatest.Succeed(),
+//	)
+//	client := server.Client(gozaya.WithRateLimitRetry(2, 5*time.Second))
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+}
+
+// NewServer starts a fake Zaya API. It should be closed when done.
+func NewServer() *Server {
+	s := &Server{}
+	for pattern, fixture := range map[string]string{
+		"GET /api/v1/links":         fixtures.ListLinks,
+		"POST /api/v1/links":        fixtures.CreateLink,
+		"GET /api/v1/links/{id}":    fixtures.GetLink,
+		"PUT /api/v1/links/{id}":    fixtures.GetLink,
+		"DELETE /api/v1/links/{id}": fixtures.RemoveLink,
+		"GET /api/v1/domains":       fixtures.ListDomains,
+		"GET /api/v1/spaces":        fixtures.ListSpaces,
+		"GET /api/v1/pixels":        fixtures.ListPixels,
+	} {
+		route, _ := parseRoute(pattern)
+		s.endpoints = append(s.endpoints, &endpoint{pattern: pattern, route: route, fixture: fixture})
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Client returns a client of the server
+func (s *Server) Client(options ...gozaya.Option) *gozaya.GoZaya {
+	return gozaya.NewClient(s.URL, options...)
+}
+
+// Script sets the responses of the endpoint matching pattern, e.g. "GET /api/v1/links/{id}",
+// replacing its previous script. The steps are played in order, one per request, then the
+// endpoint responds with its fixture again. Endpoints without a fixture respond with 404
+// once their script is played.
+func (s *Server) Script(pattern string, steps ...Step) {
+	route, err := parseRoute(pattern)
+	if err != nil {
+		panic(err)
+	}
+
+	var script []Step
+	for _, step := range steps {
+		for range max(step.times, 1) {
+			script = append(script, step)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, endpoint := range s.endpoints {
+		if endpoint.pattern == pattern {
+			endpoint.steps = script
+			return
+		}
+	}
+	// scripted routes take precedence over the routes with a fixture, e.g. a single link over {id}
+	s.endpoints = append([]*endpoint{{pattern: pattern, route: route, steps: script}}, s.endpoints...)
+}
+
+// Hits returns the number of requests received by the endpoint matching pattern
+func (s *Server) Hits(pattern string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, endpoint := range s.endpoints {
+		if endpoint.pattern == pattern {
+			return endpoint.hits
+		}
+	}
+	return 0
+}
+
+func (s *Server) serve(w http.ResponseWriter, req *http.Request) {
+	step, fixture, ok := s.next(req)
+	if !ok {
+		writeFixture(w, http.StatusNotFound, nil, fixtures.MustLoad(fixtures.ErrorNotFound))
+		return
+	}
+
+	if step.Delay > 0 {
+		timer := time.NewTimer(step.Delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			return
+		}
+	}
+
+	body := step.Body
+	if body == nil {
+		if fixture == "" {
+			writeFixture(w, http.StatusNotFound, step.Header, fixtures.MustLoad(fixtures.ErrorNotFound))
+			return
+		}
+		body = fixtures.MustLoad(fixture)
+	}
+	writeFixture(w, step.Status, step.Header, body)
+}
+
+// next pops the step of the endpoint matching req.
+func (s *Server) next(req *http.Request) (Step, string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, endpoint := range s.endpoints {
+		if !endpoint.route.match(req) {
+			continue
+		}
+		endpoint.hits++
+		var step Step
+		if len(endpoint.steps) > 0 {
+			step = endpoint.steps[0]
+			endpoint.steps = endpoint.steps[1:]
+		}
+		return step, endpoint.fixture, true
+	}
+	return Step{}, "", false
+}
+
+func writeFixture(w http.ResponseWriter, status int, header http.Header, body []byte) {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	maps.Copy(w.Header(), header)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
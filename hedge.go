@@ -0,0 +1,118 @@
+package gozaya
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// WithHedging enables hedged GET requests: when a GET request has not completed after
+// threshold, a second identical request is sent, and the first successful response of the
+// two is used while the other request is canceled. Hedging trades extra load for a shorter
+// tail latency, e.g. on GetLink in redirect paths. The hedged request goes through the rate
+// limiter like any other request, and takes its own slot of WithMaxConcurrency: the request
+// is not hedged when no slot is free. A threshold of zero or less disables hedging.
+func WithHedging(threshold time.Duration) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.hedgeThreshold = max(threshold, 0) })
+	}
+}
+
+// hedgeResult is the outcome of one of the requests of a hedged attempt
+type hedgeResult struct {
+	resp *resty.Response
+	err  error
+}
+
+// executeHedged sends a GET request, then a copy of it when the first one has not completed
+// after the hedging threshold, and returns the first successful response. When both fail,
+// the last failure is returned.
+func (g *GoZaya) executeHedged(ctx context.Context, tuning *tuning, req *resty.Request, endpoint string) (*resty.Response, error) {
+	// the copies are sent instead of req, which is left as if it had been sent
+	req.Method = http.MethodGet
+
+	ctx, cancel := context.WithCancel(ctx)
+	// cancels the request still in flight once a response is chosen
+	defer cancel()
+
+	results := make(chan hedgeResult, 2)
+	execute := func(r *resty.Request, hedge bool, release func()) {
+		defer release()
+		if hedge && tuning.rateLimiter != nil {
+			if err := tuning.rateLimiter.Wait(ctx); err != nil {
+				results <- hedgeResult{err: err}
+				return
+			}
+		}
+		resp, err := r.Execute(http.MethodGet, endpoint)
+		results <- hedgeResult{resp: resp, err: err}
+	}
+
+	go execute(g.hedgeRequest(ctx, req), false, func() {})
+	inFlight := 1
+
+	timer := time.NewTimer(tuning.hedgeThreshold)
+	defer timer.Stop()
+	hedged := false
+
+	for {
+		select {
+		case <-timer.C:
+			// the hedge is skipped rather than exceeding the concurrency limit
+			release, ok := tuning.concurrency.tryAcquire()
+			if !ok {
+				continue
+			}
+			hedged = true
+			inFlight++
+			go execute(g.hedgeRequest(ctx, req), true, release)
+		case result := <-results:
+			inFlight--
+			// a failure before the threshold is left to the retry policy
+			if !transientFailure(result.resp, result.err) || !hedged || inFlight == 0 {
+				return result.resp, result.err
+			}
+		}
+	}
+}
+
+// hedgeRequest returns a new GET request with the headers, query and authentication of req,
+// sent with ctx, so that the copies can be sent concurrently and canceled independently of
+// the original request without sharing its state.
+func (g *GoZaya) hedgeRequest(ctx context.Context, req *resty.Request) *resty.Request {
+	var errResponse HTTPErrorResponse
+	hedged := g.restyClient.R().
+		SetContext(ctx).
+		SetError(&errResponse).
+		SetBody(req.Body)
+	hedged.Header = req.Header.Clone()
+	hedged.QueryParam = cloneValues(req.QueryParam)
+	hedged.FormData = cloneValues(req.FormData)
+	hedged.PathParams = maps.Clone(req.PathParams)
+	hedged.RawPathParams = maps.Clone(req.RawPathParams)
+	hedged.Token = req.Token
+	hedged.AuthScheme = req.AuthScheme
+	hedged.Cookies = slices.Clone(req.Cookies)
+	if req.UserInfo != nil {
+		userInfo := *req.UserInfo
+		hedged.UserInfo = &userInfo
+	}
+	return hedged
+}
+
+// cloneValues returns a deep copy of values
+func cloneValues(values url.Values) url.Values {
+	if values == nil {
+		return nil
+	}
+	cloned := make(url.Values, len(values))
+	for key, value := range values {
+		cloned[key] = slices.Clone(value)
+	}
+	return cloned
+}
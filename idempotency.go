@@ -0,0 +1,70 @@
+package gozaya
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a write
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// WithIdempotencyKeys sends a generated UUID as Idempotency-Key with every POST and PATCH
+// request, such as CreateLink. The key is kept across the retries of a call, so that a retried
+// create does not produce a duplicate link when a response is lost, and such requests become
+// retryable by WithRetry.
+func WithIdempotencyKeys() func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.idempotencyKeys = true
+	}
+}
+
+type idempotencyKeyContextKey struct{}
+
+// callIdempotencyKey is the idempotency key of a call, sent with the first write only
+type callIdempotencyKey struct {
+	key  string
+	used atomic.Bool
+}
+
+// WithCallIdempotencyKey returns a context sending key as Idempotency-Key with the first
+// write made with it, e.g. a key derived from the caller's own request ID so that the
+// caller's retries are deduplicated too. The key is kept across the retries of that call;
+// the later writes made with the context, e.g. by BulkCreateLinks, do not send it, as the
+// server would deduplicate them. It takes precedence over the keys generated by
+// WithIdempotencyKeys.
+func WithCallIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, &callIdempotencyKey{key: key})
+}
+
+// idempotentMethod reports whether the requests of a method carry an idempotency key
+func idempotentMethod(method string) bool {
+	return method == http.MethodPost || method == http.MethodPatch
+}
+
+// setIdempotencyKey sets the idempotency key of a write request, if any.
+func (g *GoZaya) setIdempotencyKey(ctx context.Context, req *resty.Request, method string) {
+	if !idempotentMethod(method) || req.Header.Get(IdempotencyKeyHeader) != "" {
+		return
+	}
+	if call, _ := ctx.Value(idempotencyKeyContextKey{}).(*callIdempotencyKey); call != nil && call.key != "" && call.used.CompareAndSwap(false, true) {
+		req.SetHeader(IdempotencyKeyHeader, call.key)
+		return
+	}
+	if g.idempotencyKeys {
+		req.SetHeader(IdempotencyKeyHeader, newUUID())
+	}
+}
+
+// newUUID returns a random version 4 UUID
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,45 @@
+package gozaya
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+)
+
+// instances numbers the clients constructed in the process
+var instances atomic.Uint64
+
+// nextInstanceID returns the ID of a newly constructed client, "zaya-1" for the first one.
+func nextInstanceID() string {
+	return "zaya-" + strconv.FormatUint(instances.Add(1), 10)
+}
+
+// WithInstanceID names the client, e.g. after the tenant it serves, instead of the number
+// it is given when constructed. The ID is reported in the errors, the response metadata and the
+// context of the requests of the client.
+func WithInstanceID(id string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.instanceID = id
+	}
+}
+
+// InstanceID returns the ID of the client, numbered in the order in which the clients are
+// constructed in the process unless set with WithInstanceID. Clients derived with With are
+// numbered as new instances.
+func (g *GoZaya) InstanceID() string {
+	return g.instanceID
+}
+
+type instanceContextKey struct{}
+
+// withInstance returns a context telling the client instance of the requests made with it.
+func withInstance(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, instanceContextKey{}, id)
+}
+
+// InstanceIDFromContext returns the ID of the client a request is made by, e.g. from the
+// context of the request in an http.RoundTripper. It is empty for requests made outside the client.
+func InstanceIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(instanceContextKey{}).(string)
+	return id
+}
@@ -0,0 +1,182 @@
+// Package legacy provides the gozaya API as it was before the typed models and the
+// pointer-based request fields were introduced. Every call delegates to the gozaya core,
+// so code bases can swap imports and migrate call sites one at a time.
+//
+// Deprecated: use the gozaya package directly.
+package legacy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	gozaya "github.com/erfandiakoo/go-zaya"
+)
+
+// Client is a gozaya client exposing the legacy method signatures.
+// Methods that did not change are promoted from the embedded client.
+type Client struct {
+	*gozaya.GoZaya
+}
+
+// NewClient creates a legacy client.
+func NewClient(basePath string, options ...func(*gozaya.GoZaya)) *Client {
+	return Wrap(gozaya.NewClient(basePath, options...))
+}
+
+// Wrap returns a legacy view of an existing client.
+func Wrap(g *gozaya.GoZaya) *Client {
+	return &Client{GoZaya: g}
+}
+
+// GenerateLinkRequest is the legacy link request, where zero values mean "not set".
+type GenerateLinkRequest struct {
+	Url              string `json:"url"`
+	Alias            string `json:"alias,omitempty"`
+	Password         string `json:"password,omitempty"`
+	Space            int    `json:"space,omitempty"`
+	Disable          int    `json:"disable,omitempty"`
+	Public           int    `json:"public,omitempty"`
+	Description      string `json:"description,omitempty"`
+	ExpirationDate   string `json:"expiration_date,omitempty"`
+	ExpirationTime   string `json:"expiration_time,omitempty"`
+	ExpirationClicks int    `json:"expiration_clicks,omitempty"`
+	Domain           int    `json:"domain,omitempty"`
+	ExpirationUrl    string `json:"expiration_url,omitempty"`
+}
+
+// ResponseModel is the legacy link response.
+type ResponseModel struct {
+	Data   Data  `json:"data"`
+	Status int64 `json:"status"`
+}
+
+// Data is the legacy link model.
+type Data struct {
+	ID               int64       `json:"id"`
+	UserID           int64       `json:"user_id"`
+	Space            interface{} `json:"space"`
+	Domain           string      `json:"domain"`
+	Alias            string      `json:"alias"`
+	URL              string      `json:"url"`
+	ShortURL         string      `json:"short_url"`
+	Title            string      `json:"title"`
+	TargetType       interface{} `json:"target_type"`
+	GeoTarget        interface{} `json:"geo_target"`
+	PlatformTarget   interface{} `json:"platform_target"`
+	RotationTarget   interface{} `json:"rotation_target"`
+	LastRotation     interface{} `json:"last_rotation"`
+	Status           int64       `json:"status"`
+	Public           bool        `json:"public"`
+	Password         bool        `json:"password"`
+	ExpirationURL    string      `json:"expiration_url"`
+	ExpirationClicks string      `json:"expiration_clicks"`
+	Clicks           interface{} `json:"clicks"`
+	EndsAt           interface{} `json:"ends_at"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+// CreateLink creates a link.
+//
+// Deprecated: use gozaya.GoZaya.CreateLink.
+func (c *Client) CreateLink(ctx context.Context, token string, link *GenerateLinkRequest) (*ResponseModel, error) {
+	ctx, meta := gozaya.CaptureResponseMeta(ctx)
+	result, err := c.GoZaya.CreateLink(ctx, token, link.upgrade())
+	if err != nil {
+		return nil, err
+	}
+	return newResponseModel(result, meta.StatusCode), nil
+}
+
+// GetLink returns a link.
+//
+// Deprecated: use gozaya.GoZaya.GetLink.
+func (c *Client) GetLink(ctx context.Context, token string, id string) (*ResponseModel, error) {
+	ctx, meta := gozaya.CaptureResponseMeta(ctx)
+	result, err := c.GoZaya.GetLink(ctx, token, id)
+	if err != nil {
+		return nil, err
+	}
+	return newResponseModel(result, meta.StatusCode), nil
+}
+
+// upgrade converts the legacy request, where zero values are skipped, to the current one.
+func (r *GenerateLinkRequest) upgrade() *gozaya.GenerateLinkRequest {
+	return &gozaya.GenerateLinkRequest{
+		Url:              r.Url,
+		Alias:            r.Alias,
+		Password:         r.Password,
+		Space:            nonZero(r.Space),
+		Disable:          nonZero(r.Disable),
+		Public:           nonZero(r.Public),
+		Description:      r.Description,
+		ExpirationDate:   r.ExpirationDate,
+		ExpirationTime:   r.ExpirationTime,
+		ExpirationClicks: nonZero(r.ExpirationClicks),
+		Domain:           nonZero(r.Domain),
+		ExpirationUrl:    r.ExpirationUrl,
+	}
+}
+
+func nonZero(value int) *int {
+	if value == 0 {
+		return nil
+	}
+	return gozaya.IntP(value)
+}
+
+// newResponseModel converts a link to the legacy response model, with the status of the
+// response. A link served from the link cache, without response, has the status 200.
+func newResponseModel(link *gozaya.Link, status int) *ResponseModel {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	result := &ResponseModel{Status: int64(status)}
+	if link == nil {
+		return result
+	}
+
+	result.Data = Data{
+		ID:             link.ID,
+		UserID:         link.UserID,
+		Domain:         link.Domain,
+		Alias:          link.Alias,
+		URL:            link.LongURL,
+		ShortURL:       link.ShortURL,
+		Title:          link.Title,
+		TargetType:     raw(link.TargetType),
+		GeoTarget:      raw(link.GeoTarget),
+		PlatformTarget: raw(link.PlatformTarget),
+		RotationTarget: raw(link.RotationTarget),
+		LastRotation:   raw(link.LastRotation),
+		Status:         link.Status,
+		Public:         link.Public,
+		Password:       link.HasPassword,
+		ExpirationURL:  link.ExpirationURL,
+		Clicks:         link.Clicks,
+		CreatedAt:      link.CreatedAt,
+		UpdatedAt:      link.UpdatedAt,
+	}
+	if link.SpaceID != nil {
+		result.Data.Space = *link.SpaceID
+	}
+	if link.ExpirationClicks != nil {
+		result.Data.ExpirationClicks = strconv.FormatInt(*link.ExpirationClicks, 10)
+	}
+	if link.ExpiresAt != nil {
+		result.Data.EndsAt = *link.ExpiresAt
+	}
+	return result
+}
+
+// raw decodes a raw JSON value the way the legacy untyped fields were decoded.
+func raw(data json.RawMessage) interface{} {
+	var value interface{}
+	if len(data) == 0 || json.Unmarshal(data, &value) != nil {
+		return nil
+	}
+	return value
+}
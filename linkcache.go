@@ -0,0 +1,290 @@
+package gozaya
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultLinkCacheEntries is the size of the link cache when not set.
+	defaultLinkCacheEntries = 10000
+	// warmCacheConcurrency is the number of links fetched concurrently by WarmCache.
+	warmCacheConcurrency = 8
+	// unknownAliasTTL is how long an alias without link is cached, at most the TTL of the cache.
+	unknownAliasTTL = 10 * time.Second
+)
+
+// ErrLinkCacheDisabled is returned by WarmCache when the client has no link cache
+var ErrLinkCacheDisabled = errors.New("link cache is not enabled, see WithLinkCache")
+
+// linkCacheKey identifies a cached link, the token being hashed. The links looked up by alias
+// are identified by their alias and domain instead of their ID.
+type linkCacheKey struct {
+	token    string
+	id       string
+	alias    string
+	domainID int
+}
+
+type linkCacheEntry struct {
+	link      *Link
+	expiresAt time.Time
+}
+
+// linkCache caches the links returned by GetLink and GetLinkByAlias per token
+type linkCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[linkCacheKey]linkCacheEntry
+}
+
+// WithLinkCache caches the links returned by GetLink and GetLinkByAlias for ttl, up to
+// maxEntries links, e.g. on the redirect path. The aliases without link are cached too, for
+// at most 10 seconds. maxEntries defaults to 10000 when zero or less. The links created,
+// updated or removed through the client are evicted; changes made elsewhere are seen once the
+// cached link expires. Clients derived with With share the cache.
+func WithLinkCache(ttl time.Duration, maxEntries int) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if maxEntries <= 0 {
+			maxEntries = defaultLinkCacheEntries
+		}
+		g.linkCache = &linkCache{ttl: ttl, maxEntries: maxEntries, entries: make(map[linkCacheKey]linkCacheEntry)}
+	}
+}
+
+// key returns the cache key of a link fetched with the resolved token
+func (c *linkCache) key(token string, id string) linkCacheKey {
+	sum := sha256.Sum256([]byte(token))
+	return linkCacheKey{token: hex.EncodeToString(sum[:]), id: id}
+}
+
+// aliasKey returns the cache key of a link looked up by alias with the resolved token
+func (c *linkCache) aliasKey(token string, alias string, domainID int) linkCacheKey {
+	key := c.key(token, "")
+	key.alias, key.domainID = alias, max(domainID, 0)
+	return key
+}
+
+// get returns a copy of a cached link, and whether it is cached. The link is nil when an alias
+// is cached without link. The expired entry of key is evicted.
+func (c *linkCache) get(key linkCacheKey, now time.Time) (*Link, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	if entry.link == nil {
+		return nil, true
+	}
+	copied := *entry.link
+	return &copied, true
+}
+
+// store caches a copy of a link, or an alias without link when link is nil, evicting an
+// arbitrary entry when full.
+func (c *linkCache) store(key linkCacheKey, link *Link, now time.Time) {
+	ttl := c.ttl
+	if link == nil {
+		ttl = min(ttl, unknownAliasTTL)
+	} else {
+		copied := *link
+		link = &copied
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		for cached := range c.entries {
+			delete(c.entries, cached)
+			break
+		}
+	}
+	c.entries[key] = linkCacheEntry{link: link, expiresAt: now.Add(ttl)}
+}
+
+// invalidate evicts a link for all the tokens, including where it was looked up by alias
+func (c *linkCache) invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if key.id == id || entry.link != nil && strconv.FormatInt(entry.link.ID, 10) == id {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// invalidateAlias evicts the lookups of an alias for all the tokens and domains
+func (c *linkCache) invalidateAlias(alias string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if key.alias == alias {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// InvalidateCachedLink evicts a link from the link cache, e.g. when notified that it changed.
+// It does nothing when the client has no link cache.
+func (g *GoZaya) InvalidateCachedLink(id string) {
+	if g.linkCache != nil {
+		g.linkCache.invalidate(id)
+	}
+}
+
+// InvalidateCachedAlias evicts the lookups of an alias from the link cache, e.g. when notified
+// that a link took it. It does nothing when the client has no link cache.
+func (g *GoZaya) InvalidateCachedAlias(alias string) {
+	if g.linkCache != nil && alias != "" {
+		g.linkCache.invalidateAlias(alias)
+	}
+}
+
+// CachedLinkByAlias returns the link with the given alias from the link cache, like
+// GetLinkByAlias but without calling the API. ok is false when the lookup of the alias is not
+// cached, or the client has no link cache. The link is nil when ok is true and no link uses
+// the alias.
+func (g *GoZaya) CachedLinkByAlias(ctx context.Context, token string, alias string, domainID int) (link *Link, ok bool) {
+	if g.linkCache == nil {
+		return nil, false
+	}
+	resolved, err := g.authorize(ctx, token)
+	if err != nil {
+		return nil, false
+	}
+	return g.linkCache.get(g.linkCache.aliasKey(resolved, alias, domainID), time.Now())
+}
+
+// getCachedLinkByAlias returns a link looked up by alias from the link cache, looking it up and
+// caching it, or caching that no link uses the alias, on a miss.
+func (g *GoZaya) getCachedLinkByAlias(ctx context.Context, token string, alias string, domainID int) (*Link, error) {
+	resolved, err := g.authorize(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link by alias: %w", err)
+	}
+	key := g.linkCache.aliasKey(resolved, alias, domainID)
+	if link, ok := g.linkCache.get(key, time.Now()); ok {
+		return link, nil
+	}
+	link, err := g.findLinkByAlias(ctx, token, alias, domainID, exactAlias)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	g.linkCache.store(key, link, now)
+	if link != nil {
+		g.linkCache.store(g.linkCache.key(resolved, strconv.FormatInt(link.ID, 10)), link, now)
+	}
+	return link, nil
+}
+
+// getCachedLink returns a link from the link cache, fetching and caching it on a miss.
+func (g *GoZaya) getCachedLink(ctx context.Context, token string, id string) (*Link, error) {
+	resolved, err := g.authorize(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link: %w", err)
+	}
+	key := g.linkCache.key(resolved, id)
+	if link, ok := g.linkCache.get(key, time.Now()); ok && link != nil {
+		return link, nil
+	}
+	return g.refreshCachedLink(ctx, token, key)
+}
+
+// refreshCachedLink fetches a link and caches it. token is the token of the call rather than
+// the resolved one of key, so that the token provider drops its token when the API rejects it.
+func (g *GoZaya) refreshCachedLink(ctx context.Context, token string, key linkCacheKey) (*Link, error) {
+	link, err := g.fetchLink(ctx, token, key.id)
+	if err != nil {
+		return nil, err
+	}
+	if link != nil {
+		g.linkCache.store(key, link, time.Now())
+	}
+	return link, nil
+}
+
+// WarmCache fetches the links with the given IDs into the link cache, up to 8 at a time, e.g.
+// at startup so that the first redirects are served from the cache. The links are fetched
+// even when already cached. The returned error joins the failures of the links that could not
+// be fetched, and is ctx.Err() when ctx is done before all the links were fetched.
+func (g *GoZaya) WarmCache(ctx context.Context, token string, ids []string) error {
+	if g.linkCache == nil {
+		return ErrLinkCacheDisabled
+	}
+	resolved, err := g.authorize(ctx, token)
+	if err != nil {
+		return fmt.Errorf("failed to warm link cache: %w", err)
+	}
+
+	errs := make([]error, len(ids))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(warmCacheConcurrency, len(ids)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				if _, err := g.refreshCachedLink(ctx, token, g.linkCache.key(resolved, ids[index])); err != nil {
+					errs[index] = fmt.Errorf("link %s: %w", ids[index], err)
+				}
+			}
+		}()
+	}
+
+	for index := range ids {
+		select {
+		case indexes <- index:
+			continue
+		case <-ctx.Done():
+		}
+		break
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// KeepCacheWarm warms the link cache with the given links, then refreshes them every interval
+// until ctx is done, so that they never expire from the cache as long as interval is shorter
+// than the TTL of the cache. The failures of each round are passed to onError, which may be nil.
+// It blocks until ctx is done and is meant to be run in its own goroutine. It fails right away
+// when interval is not positive.
+func (g *GoZaya) KeepCacheWarm(ctx context.Context, token string, ids []string, interval time.Duration, onError func(error)) error {
+	if g.linkCache == nil {
+		return ErrLinkCacheDisabled
+	}
+	if interval <= 0 {
+		return fmt.Errorf("failed to keep link cache warm: interval %s is not positive", interval)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := g.WarmCache(ctx, token, ids); err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package gozaya
+
+// WithDefaultDomain creates the links in the branded domain with the given ID unless
+// GenerateLinkRequest.Domain is set. Links assigned a domain by WithDomainRotation keep it.
+func WithDefaultDomain(domainID int) func(*GoZaya) {
+	return WithDefaultLinkOptions(GenerateLinkRequest{Domain: IntP(domainID)})
+}
+
+// WithDefaultSpace creates the links in the space with the given ID unless
+// GenerateLinkRequest.Space is set.
+func WithDefaultSpace(spaceID int) func(*GoZaya) {
+	return WithDefaultLinkOptions(GenerateLinkRequest{Space: IntP(spaceID)})
+}
+
+// WithDefaultLinkOptions sets the fields of the links created by CreateLink that are not set
+// in the request, e.g. a default expiration URL or privacy. The defaults add up with the ones
+// set before, the last one set winning. Url and Alias, unique to each link, are ignored.
+func WithDefaultLinkOptions(defaults GenerateLinkRequest) func(*GoZaya) {
+	return func(g *GoZaya) {
+		merged := &defaults
+		if g.linkDefaults != nil {
+			merged = mergeLinkRequest(&defaults, g.linkDefaults)
+		}
+		merged.Url = ""
+		merged.Alias = ""
+		g.linkDefaults = merged
+	}
+}
+
+// applyLinkDefaults returns the link with the client defaults set in the fields it leaves
+// unset. The caller's request is never modified.
+func (g *GoZaya) applyLinkDefaults(link *GenerateLinkRequest) *GenerateLinkRequest {
+	if g.linkDefaults == nil || link == nil {
+		return link
+	}
+	return mergeLinkRequest(link, g.linkDefaults)
+}
+
+// mergeLinkRequest returns a copy of link with the fields it leaves unset taken from defaults.
+func mergeLinkRequest(link, defaults *GenerateLinkRequest) *GenerateLinkRequest {
+	merged := *link
+	mergeString(&merged.Url, defaults.Url)
+	mergeString(&merged.Alias, defaults.Alias)
+	mergeString(&merged.Password, defaults.Password)
+	mergeInt(&merged.Space, defaults.Space)
+	mergeInt(&merged.Disable, defaults.Disable)
+	mergeInt(&merged.Public, defaults.Public)
+	mergeString(&merged.Description, defaults.Description)
+	mergeString(&merged.ExpirationDate, defaults.ExpirationDate)
+	mergeString(&merged.ExpirationTime, defaults.ExpirationTime)
+	mergeInt(&merged.ExpirationClicks, defaults.ExpirationClicks)
+	mergeInt(&merged.Domain, defaults.Domain)
+	mergeString(&merged.ExpirationUrl, defaults.ExpirationUrl)
+	mergeInt(&merged.Privacy, defaults.Privacy)
+	mergeString(&merged.PrivacyPassword, defaults.PrivacyPassword)
+	mergeInt(&merged.Favorite, defaults.Favorite)
+	return &merged
+}
+
+func mergeString(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+func mergeInt(field **int, value *int) {
+	if *field == nil && value != nil {
+		*field = IntP(*value)
+	}
+}
@@ -0,0 +1,213 @@
+package gozaya
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// expirationDateLayout is the layout of GenerateLinkRequest.ExpirationDate
+	expirationDateLayout = "2006-01-02"
+	// expirationTimeLayout is the layout of GenerateLinkRequest.ExpirationTime
+	expirationTimeLayout = "15:04"
+)
+
+// ErrPolicyViolation is matched by the errors returned when a link request violates a
+// rule of the link policy
+var ErrPolicyViolation = errors.New("link request violates policy")
+
+// PolicyViolation is a rule of the link policy violated by a link request
+type PolicyViolation struct {
+	// Rule is the name of the violated rule, e.g. "max_expiration"
+	Rule   string
+	Field  string
+	Reason string
+}
+
+// Error stringifies the PolicyViolation
+func (v *PolicyViolation) Error() string {
+	return fmt.Sprintf("%s: %s (rule %s)", v.Field, v.Reason, v.Rule)
+}
+
+// Unwrap allows matching the violation with ErrPolicyViolation
+func (v *PolicyViolation) Unwrap() error {
+	return ErrPolicyViolation
+}
+
+// PolicyError is returned when a link request violates one or more rules of the link policy
+type PolicyError struct {
+	Violations []*PolicyViolation
+}
+
+// Error stringifies the PolicyError
+func (e *PolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Error()
+	}
+	return "link request violates policy: " + strings.Join(messages, "; ")
+}
+
+// Unwrap allows matching the error with ErrPolicyViolation and with each *PolicyViolation
+func (e *PolicyError) Unwrap() []error {
+	errs := make([]error, len(e.Violations))
+	for i, violation := range e.Violations {
+		errs[i] = violation
+	}
+	return errs
+}
+
+// LinkRule is a rule of the link policy, evaluated before a link is created or updated.
+// It returns the violations of link, nil when the request complies. On updates, link only
+// holds the changed fields.
+type LinkRule interface {
+	CheckLink(ctx context.Context, operation Operation, link *GenerateLinkRequest) []*PolicyViolation
+}
+
+// LinkRuleFunc adapts a function to the LinkRule interface
+type LinkRuleFunc func(ctx context.Context, operation Operation, link *GenerateLinkRequest) []*PolicyViolation
+
+// CheckLink calls f(ctx, operation, link)
+func (f LinkRuleFunc) CheckLink(ctx context.Context, operation Operation, link *GenerateLinkRequest) []*PolicyViolation {
+	return f(ctx, operation, link)
+}
+
+// WithLinkPolicy evaluates the rules before every CreateLink and UpdateLink. All the rules
+// are evaluated, and a request violating any of them fails with a *PolicyError listing the
+// violations, without calling the API:
+//
+//	client := NewClient(basePath, WithLinkPolicy(
+//		MaxExpiration(90*24*time.Hour),
+//		ForbiddenDestinations("*.internal", "localhost"),
+//		RequirePasswordOnDomains(3),
+//	))
+func WithLinkPolicy(rules ...LinkRule) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.linkRules = slices.Clone(rules)
+	}
+}
+
+// checkLinkPolicy evaluates the rules of the link policy against a link request.
+func (g *GoZaya) checkLinkPolicy(ctx context.Context, operation Operation, link *GenerateLinkRequest) error {
+	if len(g.linkRules) == 0 || link == nil {
+		return nil
+	}
+	var violations []*PolicyViolation
+	for _, rule := range g.linkRules {
+		violations = append(violations, rule.CheckLink(ctx, operation, link)...)
+	}
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// MaxExpiration requires the links to expire within maxLength of their creation or update.
+// New links must have an expiration date; updates are checked when they set one.
+func MaxExpiration(maxLength time.Duration) LinkRule {
+	return LinkRuleFunc(func(_ context.Context, operation Operation, link *GenerateLinkRequest) []*PolicyViolation {
+		violation := func(reason string) []*PolicyViolation {
+			return []*PolicyViolation{{Rule: "max_expiration", Field: "expiration_date", Reason: reason}}
+		}
+		if link.ExpirationDate == "" {
+			if operation == OperationCreateLink {
+				return violation("an expiration date is required")
+			}
+			return nil
+		}
+
+		expiresAt, err := parseExpiration(link.ExpirationDate, link.ExpirationTime)
+		if err != nil {
+			return violation(err.Error())
+		}
+		if expiresAt.After(time.Now().Add(maxLength)) {
+			return violation(fmt.Sprintf("expires later than %s from now", maxLength))
+		}
+		return nil
+	})
+}
+
+// parseExpiration parses the expiration date and time of a link request, in UTC, the end of
+// the day when the time is not set.
+func parseExpiration(date string, clock string) (time.Time, error) {
+	if clock == "" {
+		day, err := time.Parse(expirationDateLayout, date)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid expiration date %q", date)
+		}
+		return day.Add(24*time.Hour - time.Second), nil
+	}
+	expiresAt, err := time.Parse(expirationDateLayout+" "+expirationTimeLayout, date+" "+clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiration %q %q", date, clock)
+	}
+	return expiresAt, nil
+}
+
+// RequireSpace requires the new links to be filed in a space, one of spaceIDs when any is
+// given. Spaces are how links are grouped in Zaya, in lieu of tags. Updates are checked when
+// they move the link.
+func RequireSpace(spaceIDs ...int) LinkRule {
+	return LinkRuleFunc(func(_ context.Context, operation Operation, link *GenerateLinkRequest) []*PolicyViolation {
+		switch {
+		case link.Space == nil && operation != OperationCreateLink:
+			return nil
+		case link.Space == nil:
+			return []*PolicyViolation{{Rule: "require_space", Field: "space", Reason: "a space is required"}}
+		case len(spaceIDs) > 0 && !slices.Contains(spaceIDs, *link.Space):
+			return []*PolicyViolation{{Rule: "require_space", Field: "space", Reason: fmt.Sprintf("space %d is not allowed", *link.Space)}}
+		}
+		return nil
+	})
+}
+
+// ForbiddenDestinations forbids the destination URLs whose host matches any of the patterns.
+// Patterns are case-insensitive and use the path.Match syntax, e.g. "*.internal". The
+// destinations must be absolute http or https URLs.
+func ForbiddenDestinations(hostPatterns ...string) LinkRule {
+	patterns := newAliasPatterns(hostPatterns)
+	return LinkRuleFunc(func(_ context.Context, _ Operation, link *GenerateLinkRequest) []*PolicyViolation {
+		var violations []*PolicyViolation
+		for _, destination := range []struct{ field, url string }{{"url", link.Url}, {"expiration_url", link.ExpirationUrl}} {
+			if destination.url == "" {
+				continue
+			}
+			if reason := checkDestination(patterns, destination.url); reason != "" {
+				violations = append(violations, &PolicyViolation{Rule: "forbidden_destination", Field: destination.field, Reason: reason})
+			}
+		}
+		return violations
+	})
+}
+
+// checkDestination returns why a destination is forbidden, empty when it is allowed.
+func checkDestination(patterns aliasPatterns, destination string) string {
+	parsed, err := url.Parse(destination)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return fmt.Sprintf("%q is not an absolute http or https URL", destination)
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, host); ok {
+			return fmt.Sprintf("host %q is forbidden (matches %q)", host, pattern)
+		}
+	}
+	return ""
+}
+
+// RequirePasswordOnDomains requires the new links of the domains with the given IDs to be
+// protected by a password. Updates are checked when they move the link to one of the domains.
+func RequirePasswordOnDomains(domainIDs ...int) LinkRule {
+	return LinkRuleFunc(func(_ context.Context, _ Operation, link *GenerateLinkRequest) []*PolicyViolation {
+		if link.Domain == nil || !slices.Contains(domainIDs, *link.Domain) || link.Password != "" {
+			return nil
+		}
+		return []*PolicyViolation{{Rule: "require_password", Field: "password", Reason: fmt.Sprintf("a password is required on domain %d", *link.Domain)}}
+	})
+}
@@ -0,0 +1,168 @@
+package gozaya
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// LinkState is the lifecycle state of a link, see Link.State
+type LinkState string
+
+// States of a link
+const (
+	// LinkStateDisabled is a link that does not redirect yet or anymore, e.g. a draft
+	LinkStateDisabled LinkState = "disabled"
+	// LinkStateActive is a link that redirects
+	LinkStateActive LinkState = "active"
+	// LinkStateExpired is a link past its expiration date or clicks
+	LinkStateExpired LinkState = "expired"
+	// LinkStateArchived is an expired link that was disabled, kept for its stats
+	LinkStateArchived LinkState = "archived"
+	// LinkStateDeleted is a link removed with DeleteLink, see GoZaya.LinkState
+	LinkStateDeleted LinkState = "deleted"
+)
+
+// linkTransitions lists the states each state can move to through the transition helpers
+var linkTransitions = map[LinkState][]LinkState{
+	LinkStateDisabled: {LinkStateActive, LinkStateDeleted},
+	LinkStateActive:   {LinkStateDisabled, LinkStateDeleted},
+	LinkStateExpired:  {LinkStateArchived, LinkStateDeleted},
+	LinkStateArchived: {LinkStateDeleted},
+	LinkStateDeleted:  nil,
+}
+
+// CanTransition reports whether a link in state s can move to state to
+func (s LinkState) CanTransition(to LinkState) bool {
+	return slices.Contains(linkTransitions[s], to)
+}
+
+// State returns the lifecycle state of the link, derived from its fields. A link removed with
+// DeleteLink is only known as deleted by the client, see GoZaya.LinkState.
+func (l *Link) State() LinkState {
+	expired := l.expired(time.Now())
+	switch {
+	case l.Disabled && expired:
+		return LinkStateArchived
+	case l.Disabled:
+		return LinkStateDisabled
+	case expired:
+		return LinkStateExpired
+	default:
+		return LinkStateActive
+	}
+}
+
+// expired reports whether the link is past its expiration date or clicks.
+func (l *Link) expired(now time.Time) bool {
+	if l.ExpiresAt != nil && !l.ExpiresAt.After(now) {
+		return true
+	}
+	return l.ExpirationClicks != nil && *l.ExpirationClicks > 0 && l.Clicks >= *l.ExpirationClicks
+}
+
+// ErrInvalidTransition is matched by the errors returned when a transition helper is called
+// on a link whose state does not allow the transition
+var ErrInvalidTransition = errors.New("invalid link state transition")
+
+// TransitionError is returned when a link cannot move to a state
+type TransitionError struct {
+	LinkID int64
+	From   LinkState
+	To     LinkState
+}
+
+// Error stringifies the TransitionError
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("link %d cannot move from %s to %s", e.LinkID, e.From, e.To)
+}
+
+// Unwrap allows matching the error with ErrInvalidTransition
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// deletedLinks are the IDs of the links removed with DeleteLink, shared by the clients
+// derived with With
+type deletedLinks struct {
+	mu  sync.Mutex
+	ids map[int64]struct{}
+}
+
+// add records the removal of the link with the given ID.
+func (d *deletedLinks) add(id int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ids[id] = struct{}{}
+}
+
+// has reports whether the link with the given ID was removed.
+func (d *deletedLinks) has(id int64) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.ids[id]
+	return ok
+}
+
+// LinkState returns the lifecycle state of the link: LinkStateDeleted when it was removed with
+// DeleteLink through g, and the state derived from its fields otherwise.
+func (g *GoZaya) LinkState(link *Link) LinkState {
+	if g.deletedLinks.has(link.ID) {
+		return LinkStateDeleted
+	}
+	return link.State()
+}
+
+// checkTransition validates the move of a link to a state.
+func (g *GoZaya) checkTransition(link *Link, to LinkState) error {
+	if from := g.LinkState(link); !from.CanTransition(to) {
+		return &TransitionError{LinkID: link.ID, From: from, To: to}
+	}
+	return nil
+}
+
+// EnableLink enables a disabled link. It returns a *TransitionError without calling the API
+// when the link is not disabled.
+func (g *GoZaya) EnableLink(ctx context.Context, token string, link *Link) (*Link, error) {
+	if err := g.checkTransition(link, LinkStateActive); err != nil {
+		return nil, err
+	}
+	return g.UpdateLink(ctx, token, strconv.FormatInt(link.ID, 10), &GenerateLinkRequest{Disable: IntP(0)})
+}
+
+// DisableLink disables an active link. It returns a *TransitionError without calling the API
+// when the link is not active.
+func (g *GoZaya) DisableLink(ctx context.Context, token string, link *Link) (*Link, error) {
+	if err := g.checkTransition(link, LinkStateDisabled); err != nil {
+		return nil, err
+	}
+	return g.UpdateLink(ctx, token, strconv.FormatInt(link.ID, 10), &GenerateLinkRequest{Disable: IntP(1)})
+}
+
+// ArchiveLink disables an expired link, keeping it for its stats. It returns a
+// *TransitionError without calling the API when the link is not expired.
+func (g *GoZaya) ArchiveLink(ctx context.Context, token string, link *Link) (*Link, error) {
+	if err := g.checkTransition(link, LinkStateArchived); err != nil {
+		return nil, err
+	}
+	return g.UpdateLink(ctx, token, strconv.FormatInt(link.ID, 10), &GenerateLinkRequest{Disable: IntP(1)})
+}
+
+// DeleteLink removes a link and records it as deleted, leaving link unchanged, so that the
+// transition helpers of g reject any further change to it. It returns a *TransitionError without calling the API when the link
+// is already deleted.
+func (g *GoZaya) DeleteLink(ctx context.Context, token string, link *Link) (*RemoveLinkResponse, error) {
+	if err := g.checkTransition(link, LinkStateDeleted); err != nil {
+		return nil, err
+	}
+	resp, err := g.RemoveLink(ctx, token, strconv.FormatInt(link.ID, 10))
+	if err != nil {
+		return nil, err
+	}
+	g.deletedLinks.add(link.ID)
+	return resp, nil
+}
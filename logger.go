@@ -0,0 +1,119 @@
+package gozaya
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// LogLevels are the levels of the records logged by WithLogger
+type LogLevels struct {
+	// Request is the level of the start of the calls
+	Request slog.Level
+	// Response is the level of the calls that succeeded
+	Response slog.Level
+	// Retry is the level of the requests sent again by the retry and failover policies
+	Retry slog.Level
+	// Error is the level of the calls that failed
+	Error slog.Level
+}
+
+// DefaultLogLevels are the levels used by WithLogger unless set with WithLogLevels
+var DefaultLogLevels = LogLevels{
+	Request:  slog.LevelDebug,
+	Response: slog.LevelDebug,
+	Retry:    slog.LevelInfo,
+	Error:    slog.LevelWarn,
+}
+
+// WithLogger logs the calls to the API with logger: their start, their outcome with the status,
+// latency and attempts, and their retries, at the DefaultLogLevels or those set with
+// WithLogLevels. The logged paths and errors are redacted like the errors of the client.
+func WithLogger(logger *slog.Logger) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.logger = logger })
+	}
+}
+
+// WithLogLevels sets the levels of the records logged by WithLogger
+func WithLogLevels(levels LogLevels) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.logLevels = &levels })
+	}
+}
+
+// levels returns the log levels of the tuning
+func (t *tuning) levels() LogLevels {
+	if t.logLevels == nil {
+		return DefaultLogLevels
+	}
+	return *t.logLevels
+}
+
+// log logs a record of a call when the logger of the tuning is enabled for level.
+func (g *GoZaya) log(ctx context.Context, tuning *tuning, level slog.Level, message string, attrs func() []slog.Attr) {
+	if tuning.logger == nil || !tuning.logger.Enabled(ctx, level) {
+		return
+	}
+	base := []slog.Attr{
+		slog.String("operation", string(OperationFromContext(ctx))),
+		slog.String("instance_id", g.instanceID),
+	}
+	if id := CorrelationIDFromContext(ctx); id != "" {
+		base = append(base, slog.String("correlation_id", id))
+	}
+	tuning.logger.LogAttrs(ctx, level, message, append(base, attrs()...)...)
+}
+
+// logRequest logs the start of a call.
+func (g *GoZaya) logRequest(ctx context.Context, tuning *tuning, req *resty.Request, method string, path string) {
+	g.log(ctx, tuning, tuning.levels().Request, "zaya request", func() []slog.Attr {
+		return []slog.Attr{
+			slog.String("method", method),
+			slog.String("path", g.redact(path, requestSecrets(g, req)...)),
+		}
+	})
+}
+
+// logRetry logs a request about to be sent again, after delay, because of resp or err.
+func (g *GoZaya) logRetry(ctx context.Context, tuning *tuning, req *resty.Request, reason string, attempt int, delay time.Duration, resp *resty.Response, err error) {
+	g.log(ctx, tuning, tuning.levels().Retry, "zaya retry", func() []slog.Attr {
+		attrs := []slog.Attr{
+			slog.String("reason", reason),
+			slog.Int("attempt", attempt),
+			slog.Duration("delay", delay),
+		}
+		if resp != nil && resp.RawResponse != nil {
+			attrs = append(attrs, slog.Int("status", resp.StatusCode()))
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", g.redact(err.Error(), requestSecrets(g, req)...)))
+		}
+		return attrs
+	})
+}
+
+// logCall logs the outcome of a call, its error being already redacted.
+func (g *GoZaya) logCall(ctx context.Context, tuning *tuning, meta *CallMeta) {
+	level, message := tuning.levels().Response, "zaya response"
+	if meta.Err != nil {
+		level, message = tuning.levels().Error, "zaya error"
+	}
+	g.log(ctx, tuning, level, message, func() []slog.Attr {
+		attrs := []slog.Attr{
+			slog.String("method", meta.Method),
+			slog.Int("status", meta.StatusCode),
+			slog.Duration("latency", meta.Duration),
+			slog.Int("attempts", meta.Attempts),
+		}
+		if meta.RequestID != "" {
+			attrs = append(attrs, slog.String("request_id", meta.RequestID))
+		}
+		if meta.Err != nil {
+			attrs = append(attrs, slog.String("error", meta.Err.Error()))
+		}
+		return attrs
+	})
+}
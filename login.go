@@ -0,0 +1,112 @@
+package gozaya
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Token is a bearer token obtained by logging in
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// ExpiresAt is computed from ExpiresIn when the token is received, zero when it does not expire
+	ExpiresAt time.Time `json:"-"`
+}
+
+// tokenResponse accepts the token either at the top level or wrapped in data.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token,omitempty"`
+	TokenType    string `json:"token_type,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Data         *Token `json:"data,omitempty"`
+}
+
+// Login obtains a bearer token with the email and password of an account
+func (g *GoZaya) Login(ctx context.Context, email, password string) (*Token, error) {
+	return g.requestToken(ctx, OperationLogin, "LoginEndpoint", g.Config.LoginEndpoint, map[string]string{
+		"email":    email,
+		"password": password,
+	}, "failed to login")
+}
+
+// RefreshToken exchanges a refresh token for a new bearer token
+func (g *GoZaya) RefreshToken(ctx context.Context, refreshToken string) (*Token, error) {
+	return g.requestToken(ctx, OperationRefreshToken, "RefreshTokenEndpoint", g.Config.RefreshTokenEndpoint, map[string]string{
+		"refresh_token": refreshToken,
+	}, "failed to refresh token")
+}
+
+// LoginTokenFetcher returns a TokenFetcher logging in with email and password, then
+// refreshing the token while the API hands out refresh tokens. The fetcher is not safe for
+// concurrent use; it is meant to be used with NewRefreshingTokenProvider, which serializes
+// the fetches:
+//
+//	client := NewClient(basePath)
+//	provider := NewRefreshingTokenProvider(client.LoginTokenFetcher(email, password), 0)
+//	client = NewClient(basePath, WithTokenProvider(provider))
+func (g *GoZaya) LoginTokenFetcher(email, password string) TokenFetcher {
+	var refreshToken string
+	return func(ctx context.Context) (string, time.Time, error) {
+		var (
+			token *Token
+			err   error
+		)
+		if refreshToken != "" {
+			token, err = g.RefreshToken(ctx, refreshToken)
+		}
+		if refreshToken == "" || err != nil {
+			token, err = g.Login(ctx, email, password)
+		}
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		refreshToken = token.RefreshToken
+		return token.AccessToken, token.ExpiresAt, nil
+	}
+}
+
+// requestToken posts form to an unauthenticated token endpoint.
+func (g *GoZaya) requestToken(ctx context.Context, operation Operation, endpointName string, endpoint string, form map[string]string, errMessage string) (*Token, error) {
+	var result tokenResponse
+
+	path, err := endpointPath(endpointName, endpoint, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMessage, err)
+	}
+
+	ctx = withOperation(ctx, operation)
+	req := g.GetRequest(ctx).
+		SetHeader("Cache-Control", "no-cache").
+		SetFormData(form)
+
+	resp, err := g.send(ctx, req, http.MethodPost, path, errMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.decodeResponse(resp, &result, "failed to parse "+string(operation)+" response"); err != nil {
+		return nil, err
+	}
+
+	token := Token{
+		AccessToken:  result.AccessToken,
+		TokenType:    result.TokenType,
+		ExpiresIn:    result.ExpiresIn,
+		RefreshToken: result.RefreshToken,
+	}
+	if result.Data != nil {
+		token = *result.Data
+	}
+	if token.AccessToken == "" {
+		return nil, &APIError{Code: resp.StatusCode(), Message: errMessage + ": no access token in response"}
+	}
+	if token.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return &token, nil
+}
@@ -0,0 +1,177 @@
+package gozaya
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// String summarizes the link on a single line, e.g. "link 42 https://zaya.io/abc -> https://example.com"
+func (l *Link) String() string {
+	if l == nil {
+		return "link <nil>"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "link %d %s -> %s", l.ID, l.ShortURL, l.LongURL)
+	if state := l.State(); state != LinkStateActive {
+		b.WriteString(" (" + string(state) + ")")
+	}
+	if l.HasPassword {
+		b.WriteString(" password protected")
+	}
+	return b.String()
+}
+
+// LogValue logs the identifying fields of the link
+func (l *Link) LogValue() slog.Value {
+	if l == nil {
+		return slog.Value{}
+	}
+	attrs := []slog.Attr{
+		slog.Int64("id", l.ID),
+		slog.String("alias", l.Alias),
+		slog.String("short_url", l.ShortURL),
+		slog.String("url", l.LongURL),
+		slog.String("state", string(l.State())),
+		slog.Bool("password", l.HasPassword),
+		slog.Int64("clicks", l.Clicks),
+	}
+	if l.Domain != "" {
+		attrs = append(attrs, slog.String("domain", l.Domain))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// String summarizes the link request on a single line, the passwords redacted
+func (r *GenerateLinkRequest) String() string {
+	if r == nil {
+		return "link request <nil>"
+	}
+	var b strings.Builder
+	b.WriteString("link request " + r.Url)
+	if r.Alias != "" {
+		b.WriteString(" alias " + r.Alias)
+	}
+	if r.Domain != nil {
+		b.WriteString(" domain " + strconv.Itoa(*r.Domain))
+	}
+	if r.Password != "" {
+		b.WriteString(" password " + redacted)
+	}
+	return b.String()
+}
+
+// LogValue logs the fields set on the link request, the passwords redacted
+func (r *GenerateLinkRequest) LogValue() slog.Value {
+	if r == nil {
+		return slog.Value{}
+	}
+	form := linkForm(r)
+	var attrs []slog.Attr
+	for _, field := range slices.Sorted(maps.Keys(form)) {
+		value := form[field]
+		if field == "password" || field == "privacy_password" {
+			value = redacted
+		}
+		attrs = append(attrs, slog.String(field, value))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue logs the status, operation and request of the error along with its message
+func (apiError *APIError) LogValue() slog.Value {
+	if apiError == nil {
+		return slog.Value{}
+	}
+	attrs := []slog.Attr{slog.String("message", apiError.Message)}
+	if apiError.Code != 0 {
+		attrs = append(attrs, slog.Int("code", apiError.Code))
+	}
+	if apiError.Type != "" {
+		attrs = append(attrs, slog.String("type", string(apiError.Type)))
+	}
+	if apiError.Operation != "" {
+		attrs = append(attrs, slog.String("operation", string(apiError.Operation)))
+	}
+	if apiError.Method != "" {
+		attrs = append(attrs, slog.String("method", apiError.Method), slog.String("path", requestPath(apiError.URL)))
+	}
+	if apiError.RequestID != "" {
+		attrs = append(attrs, slog.String("request_id", apiError.RequestID))
+	}
+	if apiError.CorrelationID != "" {
+		attrs = append(attrs, slog.String("correlation_id", apiError.CorrelationID))
+	}
+	if apiError.Attempts > 0 {
+		attrs = append(attrs, slog.Int("attempts", apiError.Attempts))
+	}
+	if apiError.RetryAfter > 0 {
+		attrs = append(attrs, slog.Duration("retry_after", apiError.RetryAfter))
+	}
+	if apiError.InstanceID != "" {
+		attrs = append(attrs, slog.String("instance_id", apiError.InstanceID))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// String summarizes the stats point, e.g. "2024-05-01: 12"
+func (p *StatsPoint) String() string {
+	if p == nil {
+		return "stats point <nil>"
+	}
+	return p.Value + ": " + strconv.FormatInt(p.Count, 10)
+}
+
+// LogValue logs the value and count of the stats point
+func (p *StatsPoint) LogValue() slog.Value {
+	if p == nil {
+		return slog.Value{}
+	}
+	return slog.GroupValue(slog.String("value", p.Value), slog.Int64("count", p.Count))
+}
+
+// String summarizes the snapshot, e.g. "link 42 (abc) 2024-05-01: 12 clicks"
+func (s StatsSnapshot) String() string {
+	return fmt.Sprintf("link %d (%s) %s: %d clicks", s.LinkID, s.Alias, s.Date.Format(statsDateLayout), s.Clicks)
+}
+
+// LogValue logs the fields of the snapshot
+func (s StatsSnapshot) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("link_id", s.LinkID),
+		slog.String("alias", s.Alias),
+		slog.String("date", s.Date.Format(statsDateLayout)),
+		slog.Int64("clicks", s.Clicks),
+	)
+}
+
+// String summarizes the stats page, the password redacted
+func (p *PublicStatsPage) String() string {
+	if p == nil {
+		return "stats page <nil>"
+	}
+	s := fmt.Sprintf("stats page of link %d %s (privacy %d)", p.LinkID, p.URL, p.Privacy)
+	if p.Password != "" {
+		s += " password " + redacted
+	}
+	return s
+}
+
+// LogValue logs the fields of the stats page, the password redacted
+func (p *PublicStatsPage) LogValue() slog.Value {
+	if p == nil {
+		return slog.Value{}
+	}
+	attrs := []slog.Attr{
+		slog.Int64("link_id", p.LinkID),
+		slog.String("url", p.URL),
+		slog.Int("privacy", int(p.Privacy)),
+	}
+	if p.Password != "" {
+		attrs = append(attrs, slog.String("password", redacted))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -2,7 +2,17 @@ package gozaya
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -86,6 +96,138 @@ func (s *EnforcedString) MarshalJSON() ([]byte, error) {
 	return json.Marshal(*s)
 }
 
+// zayaTimeLayouts are the date formats used by the Zaya API
+var zayaTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// flexTime decodes a date in any of the Zaya date formats. Null and empty values decode to the zero time.
+type flexTime struct {
+	value time.Time
+}
+
+// UnmarshalJSON parses the date
+func (t *flexTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if string(data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		return nil
+	}
+	for _, layout := range zayaTimeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.value = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported date format %q", s)
+}
+
+// flexInt decodes a number that may be sent as a JSON number, a numeric string or null
+type flexInt int64
+
+// UnmarshalJSON parses the number
+func (i *flexInt) UnmarshalJSON(data []byte) error {
+	id := flexID{}
+	if err := id.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*i = flexInt(PInt64(id.value))
+	return nil
+}
+
+// flexID decodes an optional number sent as a JSON number, a numeric string,
+// an object holding an "id" or null
+type flexID struct {
+	value *int64
+}
+
+// UnmarshalJSON parses the number
+func (i *flexID) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0 || string(data) == "null" || string(data) == `""`:
+		i.value = nil
+		return nil
+	case data[0] == '{':
+		var obj struct {
+			ID flexID `json:"id"`
+		}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		i.value = obj.ID.value
+		return nil
+	case data[0] == '"':
+		data = bytes.Trim(data, `"`)
+	}
+	value, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %s", data)
+	}
+	i.value = &value
+	return nil
+}
+
+// flexBool decodes a boolean sent as a JSON boolean, a number, a numeric string or null
+type flexBool bool
+
+// UnmarshalJSON parses the boolean
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch string(bytes.Trim(data, `"`)) {
+	case "true", "1":
+		*b = true
+	case "false", "0", "", "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+// flexDomain decodes a domain sent either as its name or as a domain object
+type flexDomain string
+
+// UnmarshalJSON parses the domain
+func (d *flexDomain) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var obj struct {
+			URL  string `json:"url"`
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		if obj.URL != "" {
+			*d = flexDomain(obj.URL)
+		} else {
+			*d = flexDomain(obj.Name)
+		}
+		return nil
+	}
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*d = flexDomain(PString(s))
+	return nil
+}
+
+// nullToEmpty drops JSON null values so that they are omitted when re-encoded.
+func nullToEmpty(data json.RawMessage) json.RawMessage {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	return data
+}
+
 // APIErrType is a field containing more specific API error types
 // that may be checked by the receiver.
 type APIErrType string
@@ -98,6 +240,30 @@ const (
 	// APIErrTypeInvalidGrant corresponds with Keycloak's
 	// OAuthErrorException due to "invalid_grant".
 	APIErrTypeInvalidGrant = "oauth: invalid grant"
+
+	// APIErrTypeTimeout is for requests that timed out, including those
+	// whose context deadline was exceeded.
+	APIErrTypeTimeout APIErrType = "timeout"
+
+	// APIErrTypeCanceled is for requests whose context was canceled.
+	APIErrTypeCanceled APIErrType = "canceled"
+
+	// APIErrTypeDNS is for requests whose host could not be resolved.
+	APIErrTypeDNS APIErrType = "dns"
+
+	// APIErrTypeTLS is for requests that failed the TLS handshake,
+	// e.g. on an untrusted certificate.
+	APIErrTypeTLS APIErrType = "tls"
+
+	// APIErrTypeNetwork is for the other connection failures, such as
+	// a refused or reset connection.
+	APIErrTypeNetwork APIErrType = "network"
+
+	// APIErrTypeRateLimited is for 429 Too Many Requests responses.
+	APIErrTypeRateLimited APIErrType = "rate_limited"
+
+	// APIErrTypeServer is for 5xx responses.
+	APIErrTypeServer APIErrType = "server"
 )
 
 // ParseAPIErrType is a convenience method for returning strongly
@@ -106,9 +272,46 @@ func ParseAPIErrType(err error) APIErrType {
 	if err == nil {
 		return APIErrTypeUnknown
 	}
+
+	var (
+		dnsErr         *net.DNSError
+		netErr         net.Error
+		opErr          *net.OpError
+		recordErr      tls.RecordHeaderError
+		alertErr       tls.AlertError
+		verifyErr      *tls.CertificateVerificationError
+		authorityErr   x509.UnknownAuthorityError
+		hostnameErr    x509.HostnameError
+		certInvalidErr x509.CertificateInvalidError
+	)
 	switch {
 	case strings.Contains(err.Error(), "invalid_grant"):
 		return APIErrTypeInvalidGrant
+	case errors.Is(err, context.Canceled):
+		return APIErrTypeCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return APIErrTypeTimeout
+	case errors.As(err, &dnsErr):
+		return APIErrTypeDNS
+	case errors.As(err, &recordErr), errors.As(err, &alertErr), errors.As(err, &verifyErr),
+		errors.As(err, &authorityErr), errors.As(err, &hostnameErr), errors.As(err, &certInvalidErr):
+		return APIErrTypeTLS
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return APIErrTypeTimeout
+	case errors.As(err, &opErr), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return APIErrTypeNetwork
+	default:
+		return APIErrTypeUnknown
+	}
+}
+
+// statusErrType returns the type of the error of a response with the given status code.
+func statusErrType(code int) APIErrType {
+	switch {
+	case code == http.StatusTooManyRequests:
+		return APIErrTypeRateLimited
+	case code >= 500:
+		return APIErrTypeServer
 	default:
 		return APIErrTypeUnknown
 	}
@@ -119,56 +322,383 @@ type APIError struct {
 	Code    int        `json:"code"`
 	Message string     `json:"message"`
 	Type    APIErrType `json:"type"`
+
+	// RetryAfter is the delay requested by the server through the Retry-After header,
+	// typically on 429 and 503 responses, 0 when not requested
+	RetryAfter time.Duration `json:"retry_after,omitempty"`
+
+	// Attempts is the number of times the request was sent, retries included
+	Attempts int `json:"attempts,omitempty"`
+	// InstanceID is the ID of the client that made the request, see GoZaya.InstanceID
+	InstanceID string `json:"instance_id,omitempty"`
+
+	// Operation is the operation the failed request belongs to
+	Operation Operation `json:"operation,omitempty"`
+	// Method and URL are those of the failed request, the URL redacted
+	Method string `json:"method,omitempty"`
+	URL    string `json:"url,omitempty"`
+	// RequestID is the ID given to the request by the server, to find it in the server logs
+	RequestID string `json:"request_id,omitempty"`
+	// CorrelationID is the correlation ID sent with the request, see WithCorrelationID
+	CorrelationID string `json:"correlation_id,omitempty"`
+	// Header and Body are those of the error response, redacted like the message, nil when no
+	// response was received
+	Header http.Header `json:"-"`
+	Body   []byte      `json:"-"`
+	// Err is the cause of the failures without a response, e.g. a connection reset
+	Err error `json:"-"`
 }
 
-// Error stringifies the APIError
+// Error stringifies the APIError, prefixed with the operation and the request when known
+// and followed by the request ID, e.g.
+// "create link: POST /api/v1/links: 422 Unprocessable Entity: ... (request ID 4f2a)"
 func (apiError APIError) Error() string {
-	return apiError.Message
+	var b strings.Builder
+	if apiError.Operation != "" {
+		b.WriteString(strings.ReplaceAll(string(apiError.Operation), "_", " "))
+		b.WriteString(": ")
+	}
+	if apiError.Method != "" {
+		b.WriteString(apiError.Method)
+		if path := requestPath(apiError.URL); path != "" {
+			b.WriteString(" " + path)
+		}
+		b.WriteString(": ")
+	}
+	b.WriteString(apiError.Message)
+	if apiError.RequestID != "" {
+		b.WriteString(" (request ID " + apiError.RequestID + ")")
+	}
+	return b.String()
+}
+
+// requestPath returns the path of a request URL, leaving out the host and the query, which
+// may hold credentials.
+func requestPath(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.EscapedPath()
 }
 
+// IsRetryable reports whether the request may succeed if sent again as is: on timeouts, reset
+// or refused connections, temporary DNS failures, 429 responses and 502, 503 and 504 responses.
+// It does not tell whether retrying is safe; a failed POST may have been processed, see
+// WithIdempotencyKeys.
+func (apiError *APIError) IsRetryable() bool {
+	switch apiError.Type {
+	case APIErrTypeRateLimited:
+		return true
+	case APIErrTypeServer:
+		return apiError.Code == http.StatusBadGateway || apiError.Code == http.StatusServiceUnavailable || apiError.Code == http.StatusGatewayTimeout
+	case APIErrTypeTimeout, APIErrTypeNetwork, APIErrTypeDNS:
+		return apiError.Err != nil && transientError(apiError.Err)
+	default:
+		return false
+	}
+}
+
+// Unwrap returns the cause of the error, nil when the API responded
+func (apiError *APIError) Unwrap() error {
+	return apiError.Err
+}
+
+// GenerateLinkRequest holds the parameters of a link to create.
+// Optional numeric fields are pointers so that zero values can be sent explicitly,
+// use IntP to set them.
 type GenerateLinkRequest struct {
-	Url              string `json:"url"`
+	Url              string `json:"url,omitempty"`
 	Alias            string `json:"alias,omitempty"`
 	Password         string `json:"password,omitempty"`
-	Space            int    `json:"space,omitempty"`
-	Disable          int    `json:"disable,omitempty"`
-	Public           int    `json:"public,omitempty"`
+	Space            *int   `json:"space,omitempty"`
+	Disable          *int   `json:"disable,omitempty"`
+	Public           *int   `json:"public,omitempty"`
 	Description      string `json:"description,omitempty"`
 	ExpirationDate   string `json:"expiration_date,omitempty"`
 	ExpirationTime   string `json:"expiration_time,omitempty"`
-	ExpirationClicks int    `json:"expiration_clicks,omitempty"`
-	Domain           int    `json:"domain,omitempty"`
+	ExpirationClicks *int   `json:"expiration_clicks,omitempty"`
+	Domain           *int   `json:"domain,omitempty"`
 	ExpirationUrl    string `json:"expiration_url,omitempty"`
+	// Privacy is the privacy of the stats page, one of the StatsPrivacy values.
+	Privacy         *int   `json:"privacy,omitempty"`
+	PrivacyPassword string `json:"privacy_password,omitempty"`
+	// Favorite stars (1) or unstars (0) the link.
+	Favorite *int `json:"favorite,omitempty"`
+}
+
+// Link is a short link
+type Link struct {
+	ID               int64           `json:"id"`
+	UserID           int64           `json:"user_id"`
+	SpaceID          *int64          `json:"space,omitempty"`
+	Domain           string          `json:"domain"`
+	Alias            string          `json:"alias"`
+	LongURL          string          `json:"url"`
+	ShortURL         string          `json:"short_url"`
+	Title            string          `json:"title"`
+	Status           int64           `json:"status"`
+	Public           bool            `json:"public"`
+	HasPassword      bool            `json:"password"`
+	Disabled         bool            `json:"disabled"`
+	StatsPrivacy     StatsPrivacy    `json:"privacy"`
+	Favorite         bool            `json:"favorite"`
+	Clicks           int64           `json:"clicks"`
+	ExpirationURL    string          `json:"expiration_url,omitempty"`
+	ExpirationClicks *int64          `json:"expiration_clicks,omitempty"`
+	TargetType       json.RawMessage `json:"target_type,omitempty"`
+	GeoTarget        json.RawMessage `json:"geo_target,omitempty"`
+	PlatformTarget   json.RawMessage `json:"platform_target,omitempty"`
+	RotationTarget   json.RawMessage `json:"rotation_target,omitempty"`
+	LastRotation     json.RawMessage `json:"last_rotation,omitempty"`
+	ExpiresAt        *time.Time      `json:"ends_at,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+// linkJSON is the wire representation of a link.
+// Zaya is loose with the types of some fields, so these are decoded leniently.
+type linkJSON struct {
+	ID               int64           `json:"id" required:"true"`
+	UserID           int64           `json:"user_id"`
+	Space            flexID          `json:"space"`
+	Domain           flexDomain      `json:"domain"`
+	Alias            string          `json:"alias"`
+	URL              string          `json:"url" required:"true"`
+	ShortURL         string          `json:"short_url"`
+	Title            string          `json:"title"`
+	Status           flexInt         `json:"status"`
+	Public           flexBool        `json:"public"`
+	Password         flexBool        `json:"password"`
+	Disabled         flexBool        `json:"disabled"`
+	Privacy          flexInt         `json:"privacy"`
+	Favorite         flexBool        `json:"favorite"`
+	Clicks           flexInt         `json:"clicks"`
+	ExpirationURL    string          `json:"expiration_url"`
+	ExpirationClicks flexID          `json:"expiration_clicks"`
+	TargetType       json.RawMessage `json:"target_type"`
+	GeoTarget        json.RawMessage `json:"geo_target"`
+	PlatformTarget   json.RawMessage `json:"platform_target"`
+	RotationTarget   json.RawMessage `json:"rotation_target"`
+	LastRotation     json.RawMessage `json:"last_rotation"`
+	EndsAt           flexTime        `json:"ends_at"`
+	CreatedAt        flexTime        `json:"created_at"`
+	UpdatedAt        flexTime        `json:"updated_at"`
+}
+
+func (l linkJSON) link() *Link {
+	link := &Link{
+		ID:               l.ID,
+		UserID:           l.UserID,
+		SpaceID:          l.Space.value,
+		Domain:           string(l.Domain),
+		Alias:            l.Alias,
+		LongURL:          l.URL,
+		ShortURL:         l.ShortURL,
+		Title:            l.Title,
+		Status:           int64(l.Status),
+		Public:           bool(l.Public),
+		HasPassword:      bool(l.Password),
+		Disabled:         bool(l.Disabled),
+		StatsPrivacy:     StatsPrivacy(l.Privacy),
+		Favorite:         bool(l.Favorite),
+		Clicks:           int64(l.Clicks),
+		ExpirationURL:    l.ExpirationURL,
+		ExpirationClicks: l.ExpirationClicks.value,
+		TargetType:       nullToEmpty(l.TargetType),
+		GeoTarget:        nullToEmpty(l.GeoTarget),
+		PlatformTarget:   nullToEmpty(l.PlatformTarget),
+		RotationTarget:   nullToEmpty(l.RotationTarget),
+		LastRotation:     nullToEmpty(l.LastRotation),
+		CreatedAt:        l.CreatedAt.value,
+		UpdatedAt:        l.UpdatedAt.value,
+	}
+	if !l.EndsAt.value.IsZero() {
+		endsAt := l.EndsAt.value
+		link.ExpiresAt = &endsAt
+	}
+	return link
+}
+
+// jsonShape returns the wire representation of a link, used by the strict decoding mode
+func (l *Link) jsonShape() interface{} {
+	return linkJSON{}
 }
 
-type ResponseModel struct {
-	Data   Data  `json:"data"`
+// UnmarshalJSON decodes a link from the Zaya API representation,
+// accepting the different date formats used by the API.
+func (l *Link) UnmarshalJSON(data []byte) error {
+	var raw linkJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*l = *raw.link()
+	return nil
+}
+
+// linkResponse is the envelope of the single link endpoints
+type linkResponse struct {
+	Data   *Link `json:"data" required:"true"`
 	Status int64 `json:"status"`
 }
 
-type Data struct {
-	ID               int64       `json:"id"`
-	UserID           int64       `json:"user_id"`
-	Space            interface{} `json:"space"`
-	Domain           string      `json:"domain"`
-	Alias            string      `json:"alias"`
-	URL              string      `json:"url"`
-	ShortURL         string      `json:"short_url"`
-	Title            string      `json:"title"`
-	TargetType       interface{} `json:"target_type"`
-	GeoTarget        interface{} `json:"geo_target"`
-	PlatformTarget   interface{} `json:"platform_target"`
-	RotationTarget   interface{} `json:"rotation_target"`
-	LastRotation     interface{} `json:"last_rotation"`
-	Status           int64       `json:"status"`
-	Public           bool        `json:"public"`
-	Password         bool        `json:"password"`
-	ExpirationURL    string      `json:"expiration_url"`
-	ExpirationClicks string      `json:"expiration_clicks"`
-	Clicks           interface{} `json:"clicks"`
-	EndsAt           interface{} `json:"ends_at"`
-	CreatedAt        time.Time   `json:"created_at"`
-	UpdatedAt        time.Time   `json:"updated_at"`
+// GetLinksParams represents the optional parameters for listing links
+type GetLinksParams struct {
+	Search   *string `json:"search,omitempty"`
+	SearchBy *string `json:"search_by,omitempty"`
+	Status   *int    `json:"status,string,omitempty"`
+	Space    *int    `json:"space,string,omitempty"`
+	Domain   *int    `json:"domain,string,omitempty"`
+	Pixel    *int    `json:"pixel,string,omitempty"`
+	Favorite *int    `json:"favorite,string,omitempty"`
+	Sort     *string `json:"sort,omitempty"`
+	Page     *int    `json:"page,string,omitempty"`
+	PerPage  *int    `json:"per_page,string,omitempty"`
+	// UpdatedSince lists the links updated at or after an RFC 3339 time, e.g. to poll changes
+	UpdatedSince *string `json:"updated_since,omitempty"`
+}
+
+// ListParams represents the optional parameters for listing domains, spaces and pixels
+type ListParams struct {
+	Search   *string `json:"search,omitempty"`
+	SearchBy *string `json:"search_by,omitempty"`
+	Sort     *string `json:"sort,omitempty"`
+	Page     *int    `json:"page,string,omitempty"`
+	PerPage  *int    `json:"per_page,string,omitempty"`
+}
+
+// Domain is a branded domain
+type Domain struct {
+	ID           int64     `json:"id" required:"true"`
+	Name         string    `json:"name"`
+	URL          string    `json:"url,omitempty"`
+	IndexPage    string    `json:"index_page,omitempty"`
+	NotFoundPage string    `json:"not_found_page,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Space is a group of links
+type Space struct {
+	ID        int64     `json:"id" required:"true"`
+	Name      string    `json:"name"`
+	Color     int64     `json:"color,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Pixel is a retargeting pixel
+type Pixel struct {
+	ID        int64     `json:"id" required:"true"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// domainJSON is the wire representation of a domain, whose dates are decoded leniently
+type domainJSON struct {
+	ID           int64    `json:"id" required:"true"`
+	Name         string   `json:"name"`
+	URL          string   `json:"url"`
+	IndexPage    string   `json:"index_page"`
+	NotFoundPage string   `json:"not_found_page"`
+	CreatedAt    flexTime `json:"created_at"`
+	UpdatedAt    flexTime `json:"updated_at"`
+}
+
+// jsonShape returns the wire representation of a domain, used by the strict decoding mode
+func (d *Domain) jsonShape() interface{} {
+	return domainJSON{}
+}
+
+// UnmarshalJSON decodes a domain, accepting the different date formats used by the API
+func (d *Domain) UnmarshalJSON(data []byte) error {
+	var raw domainJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*d = Domain{
+		ID:           raw.ID,
+		Name:         raw.Name,
+		URL:          raw.URL,
+		IndexPage:    raw.IndexPage,
+		NotFoundPage: raw.NotFoundPage,
+		CreatedAt:    raw.CreatedAt.value,
+		UpdatedAt:    raw.UpdatedAt.value,
+	}
+	return nil
+}
+
+// spaceJSON is the wire representation of a space, whose dates are decoded leniently
+type spaceJSON struct {
+	ID        int64    `json:"id" required:"true"`
+	Name      string   `json:"name"`
+	Color     int64    `json:"color"`
+	CreatedAt flexTime `json:"created_at"`
+	UpdatedAt flexTime `json:"updated_at"`
+}
+
+// jsonShape returns the wire representation of a space, used by the strict decoding mode
+func (s *Space) jsonShape() interface{} {
+	return spaceJSON{}
+}
+
+// UnmarshalJSON decodes a space, accepting the different date formats used by the API
+func (s *Space) UnmarshalJSON(data []byte) error {
+	var raw spaceJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = Space{
+		ID:        raw.ID,
+		Name:      raw.Name,
+		Color:     raw.Color,
+		CreatedAt: raw.CreatedAt.value,
+		UpdatedAt: raw.UpdatedAt.value,
+	}
+	return nil
+}
+
+// pixelJSON is the wire representation of a pixel, whose dates are decoded leniently
+type pixelJSON struct {
+	ID        int64    `json:"id" required:"true"`
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Value     string   `json:"value"`
+	CreatedAt flexTime `json:"created_at"`
+	UpdatedAt flexTime `json:"updated_at"`
+}
+
+// jsonShape returns the wire representation of a pixel, used by the strict decoding mode
+func (p *Pixel) jsonShape() interface{} {
+	return pixelJSON{}
+}
+
+// UnmarshalJSON decodes a pixel, accepting the different date formats used by the API
+func (p *Pixel) UnmarshalJSON(data []byte) error {
+	var raw pixelJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*p = Pixel{
+		ID:        raw.ID,
+		Name:      raw.Name,
+		Type:      raw.Type,
+		Value:     raw.Value,
+		CreatedAt: raw.CreatedAt.value,
+		UpdatedAt: raw.UpdatedAt.value,
+	}
+	return nil
+}
+
+// listResponse is the envelope of the list endpoints
+type listResponse[T any] struct {
+	Data   []T       `json:"data" required:"true"`
+	Links  pageLinks `json:"links"`
+	Meta   pageMeta  `json:"meta"`
+	Status int64     `json:"status"`
 }
 
 type RemoveLinkResponse struct {
@@ -0,0 +1,328 @@
+package gozaya
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erfandiakoo/go-zaya/fixtures"
+)
+
+func TestLinkUnmarshalFixtures(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    Link
+	}{
+		{
+			name:    "get link",
+			fixture: fixtures.GetLink,
+			want: Link{
+				ID:               1042,
+				UserID:           7,
+				Domain:           "https://go.example.com",
+				Alias:            "spring-sale",
+				LongURL:          "https://www.example.com/campaigns/spring?utm_source=zaya",
+				ShortURL:         "https://go.example.com/spring-sale",
+				Title:            "Spring sale",
+				Status:           1,
+				Public:           true,
+				Clicks:           1337,
+				ExpirationURL:    "https://www.example.com/campaigns",
+				ExpirationClicks: Int64P(5000),
+				ExpiresAt:        timeP(time.Date(2025, 6, 30, 23, 59, 59, 0, time.UTC)),
+				CreatedAt:        time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC),
+				UpdatedAt:        time.Date(2025, 3, 2, 11, 45, 12, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got linkResponse
+			if err := json.Unmarshal(fixtures.MustLoad(tt.fixture), &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got.Data == nil {
+				t.Fatal("Unmarshal() decoded no link")
+			}
+			assertLink(t, got.Data, &tt.want)
+		})
+	}
+}
+
+func TestLinkUnmarshalVariants(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    Link
+		wantErr bool
+	}{
+		{
+			name: "numbers as numbers",
+			json: `{"id": 1, "status": 1, "clicks": 12, "privacy": 2, "space": 4, "expiration_clicks": 10}`,
+			want: Link{ID: 1, Status: 1, Clicks: 12, StatsPrivacy: 2, SpaceID: Int64P(4), ExpirationClicks: Int64P(10)},
+		},
+		{
+			name: "numbers as strings",
+			json: `{"id": 1, "status": "1", "clicks": "12", "privacy": "2", "space": "4", "expiration_clicks": "10"}`,
+			want: Link{ID: 1, Status: 1, Clicks: 12, StatsPrivacy: 2, SpaceID: Int64P(4), ExpirationClicks: Int64P(10)},
+		},
+		{
+			name: "numbers as null",
+			json: `{"id": 1, "status": null, "clicks": null, "privacy": null, "space": null, "expiration_clicks": null}`,
+			want: Link{ID: 1},
+		},
+		{
+			name: "space as object",
+			json: `{"id": 1, "space": {"id": 4, "name": "Campaigns"}}`,
+			want: Link{ID: 1, SpaceID: Int64P(4)},
+		},
+		{
+			name: "booleans as numbers and strings",
+			json: `{"id": 1, "public": 1, "password": "1", "disabled": "0", "favorite": true}`,
+			want: Link{ID: 1, Public: true, HasPassword: true, Favorite: true},
+		},
+		{
+			name: "domain as name",
+			json: `{"id": 1, "domain": "go.example.com"}`,
+			want: Link{ID: 1, Domain: "go.example.com"},
+		},
+		{
+			name: "dates as null and empty",
+			json: `{"id": 1, "created_at": null, "updated_at": "", "ends_at": null}`,
+			want: Link{ID: 1},
+		},
+		{
+			name: "dates in every format",
+			json: `{"id": 1, "created_at": "2025-03-01T09:30:00.000000Z", "updated_at": "2025-03-02 11:45:12", "ends_at": "2025-06-30"}`,
+			want: Link{
+				ID:        1,
+				CreatedAt: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2025, 3, 2, 11, 45, 12, 0, time.UTC),
+				ExpiresAt: timeP(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:    "invalid date",
+			json:    `{"id": 1, "created_at": "March 1st"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid number",
+			json:    `{"id": 1, "clicks": "many"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid boolean",
+			json:    `{"id": 1, "public": "yes"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Link
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			assertLink(t, &got, &tt.want)
+		})
+	}
+}
+
+func TestFlexTimeUnmarshal(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    time.Time
+		wantErr bool
+	}{
+		{json: `null`},
+		{json: `""`},
+		{json: `"2025-03-01T09:30:00Z"`, want: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)},
+		{json: `"2025-03-01T09:30:00.123456+02:00"`, want: time.Date(2025, 3, 1, 7, 30, 0, 123456000, time.UTC)},
+		{json: `"2025-03-01 09:30:00"`, want: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)},
+		{json: `"2025-03-01T09:30:00"`, want: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)},
+		{json: `"2025-03-01"`, want: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{json: `"01/03/2025"`, wantErr: true},
+		{json: `1740821400`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			var got flexTime
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.value.Equal(tt.want) {
+				t.Errorf("Unmarshal() = %v, want %v", got.value, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlexIntUnmarshal(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    flexInt
+		wantErr bool
+	}{
+		{json: `42`, want: 42},
+		{json: `"42"`, want: 42},
+		{json: `null`, want: 0},
+		{json: `""`, want: 0},
+		{json: `{"id": 42}`, want: 42},
+		{json: `"forty-two"`, wantErr: true},
+		{json: `4.2`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			var got flexInt
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModelsUnmarshalDates(t *testing.T) {
+	payload := []byte(`{"id": 1, "created_at": "2025-03-01 09:30:00", "updated_at": null}`)
+	want := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		decode func() (createdAt, updatedAt time.Time, err error)
+	}{
+		{"domain", func() (time.Time, time.Time, error) {
+			var domain Domain
+			err := json.Unmarshal(payload, &domain)
+			return domain.CreatedAt, domain.UpdatedAt, err
+		}},
+		{"space", func() (time.Time, time.Time, error) {
+			var space Space
+			err := json.Unmarshal(payload, &space)
+			return space.CreatedAt, space.UpdatedAt, err
+		}},
+		{"pixel", func() (time.Time, time.Time, error) {
+			var pixel Pixel
+			err := json.Unmarshal(payload, &pixel)
+			return pixel.CreatedAt, pixel.UpdatedAt, err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createdAt, updatedAt, err := tt.decode()
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !createdAt.Equal(want) {
+				t.Errorf("CreatedAt = %v, want %v", createdAt, want)
+			}
+			if !updatedAt.IsZero() {
+				t.Errorf("UpdatedAt = %v, want zero", updatedAt)
+			}
+		})
+	}
+}
+
+func TestClientDecodesLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost:
+			_, _ = w.Write(fixtures.MustLoad(fixtures.CreateLink))
+		case strings.HasSuffix(r.URL.Path, "/links"):
+			_, _ = w.Write(fixtures.MustLoad(fixtures.ListLinks))
+		default:
+			_, _ = w.Write(fixtures.MustLoad(fixtures.GetLink))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+
+	created, err := client.CreateLink(ctx, "token", &GenerateLinkRequest{Url: "https://www.example.com"})
+	if err != nil {
+		t.Fatalf("CreateLink() error = %v", err)
+	}
+	if created == nil || created.ID == 0 || created.ShortURL == "" {
+		t.Errorf("CreateLink() = %+v, want a populated link", created)
+	}
+
+	link, err := client.GetLink(ctx, "token", "1042")
+	if err != nil {
+		t.Fatalf("GetLink() error = %v", err)
+	}
+	if link == nil || link.ID != 1042 || link.Alias != "spring-sale" || link.Clicks != 1337 {
+		t.Errorf("GetLink() = %+v, want link 1042", link)
+	}
+
+	links, page, err := client.ListLinks(ctx, "token", GetLinksParams{})
+	if err != nil {
+		t.Fatalf("ListLinks() error = %v", err)
+	}
+	if len(links) == 0 || links[0].ID == 0 || page == nil || page.CurrentPage != 1 {
+		t.Errorf("ListLinks() = %+v, %+v, want a populated page", links, page)
+	}
+}
+
+func assertLink(t *testing.T, got, want *Link) {
+	t.Helper()
+	checks := []struct {
+		field     string
+		got, want interface{}
+	}{
+		{"ID", got.ID, want.ID},
+		{"UserID", got.UserID, want.UserID},
+		{"SpaceID", PInt64(got.SpaceID), PInt64(want.SpaceID)},
+		{"SpaceID set", got.SpaceID != nil, want.SpaceID != nil},
+		{"Domain", got.Domain, want.Domain},
+		{"Alias", got.Alias, want.Alias},
+		{"LongURL", got.LongURL, want.LongURL},
+		{"ShortURL", got.ShortURL, want.ShortURL},
+		{"Title", got.Title, want.Title},
+		{"Status", got.Status, want.Status},
+		{"Public", got.Public, want.Public},
+		{"HasPassword", got.HasPassword, want.HasPassword},
+		{"Disabled", got.Disabled, want.Disabled},
+		{"StatsPrivacy", got.StatsPrivacy, want.StatsPrivacy},
+		{"Favorite", got.Favorite, want.Favorite},
+		{"Clicks", got.Clicks, want.Clicks},
+		{"ExpirationURL", got.ExpirationURL, want.ExpirationURL},
+		{"ExpirationClicks", PInt64(got.ExpirationClicks), PInt64(want.ExpirationClicks)},
+		{"ExpiresAt set", got.ExpiresAt != nil, want.ExpiresAt != nil},
+		{"CreatedAt", got.CreatedAt.UTC(), want.CreatedAt},
+		{"UpdatedAt", got.UpdatedAt.UTC(), want.UpdatedAt},
+	}
+	if got.ExpiresAt != nil && want.ExpiresAt != nil {
+		checks = append(checks, struct {
+			field     string
+			got, want interface{}
+		}{"ExpiresAt", got.ExpiresAt.UTC(), *want.ExpiresAt})
+	}
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s = %v, want %v", check.field, check.got, check.want)
+		}
+	}
+}
+
+func timeP(value time.Time) *time.Time {
+	return &value
+}
@@ -0,0 +1,113 @@
+package gozaya
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Executor calls an endpoint registered with NewOperation. In is the type of the request,
+// sent as query parameters for GET, HEAD and DELETE requests and as the body otherwise;
+// Out is the type the response is decoded into.
+type Executor[In, Out any] struct {
+	client       *GoZaya
+	name         Operation
+	method       string
+	pathTemplate string
+}
+
+// NewOperation registers an endpoint the client does not wrap yet, e.g.
+//
+//	stats := gozaya.NewOperation[gozaya.ListParams, StatsResponse](client, "link_stats", http.MethodGet, "api/v1/stats/{id}")
+//	result, err := stats.Call(ctx, "", map[string]string{"id": "42"}, gozaya.ListParams{})
+//
+// The calls get the same authentication, rate limiting, error handling, response hooks and
+// decoding as the wrapped endpoints. pathTemplate is relative to the base path, and its
+// {name} placeholders are replaced with the escaped path parameters of the call. Bodies are
+// JSON-encoded unless an encoding or an encoder is configured for the operation name.
+// The executor is bound to g: clients derived from g with With need their own executors.
+func NewOperation[In, Out any](g *GoZaya, name Operation, method string, pathTemplate string) *Executor[In, Out] {
+	return &Executor[In, Out]{
+		client:       g,
+		name:         name,
+		method:       strings.ToUpper(method),
+		pathTemplate: strings.TrimLeft(pathTemplate, urlSeparator),
+	}
+}
+
+// Call calls the endpoint with the given path parameters and request
+func (e *Executor[In, Out]) Call(ctx context.Context, token string, pathParams map[string]string, in In) (Out, error) {
+	var out Out
+
+	path, err := expandPath(e.pathTemplate, pathParams)
+	if err != nil {
+		return out, fmt.Errorf("failed to call %s: %w", e.name, err)
+	}
+	errMessage := "failed to call " + string(e.name)
+
+	g := e.client
+	ctx = withOperation(ctx, e.name)
+	req := g.GetRequestWithBearerAuthNoCache(ctx, token)
+	switch e.method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		queryParams, err := GetQueryParams(in)
+		if err != nil {
+			return out, fmt.Errorf("failed to build %s query: %w", e.name, err)
+		}
+		req.SetQueryParams(queryParams)
+	default:
+		encoder := g.operationEncoder(e.name)
+		body, err := encoder.Encode(in)
+		if err != nil {
+			return out, fmt.Errorf("%s: failed to encode body: %w", errMessage, err)
+		}
+		req.SetHeader("Content-Type", encoder.ContentType()).SetBody(body)
+	}
+
+	resp, err := g.execute(ctx, req, e.method, path, errMessage)
+	if err != nil {
+		return out, err
+	}
+
+	if err := g.decodeResponse(resp, &out, "failed to parse "+string(e.name)+" response"); err != nil {
+		return out, err
+	}
+
+	return out, nil
+}
+
+// operationEncoder returns the body encoder of a custom operation, JSON by default.
+func (g *GoZaya) operationEncoder(operation Operation) BodyEncoder {
+	if encoder, ok := g.operationEncoders[operation]; ok {
+		return encoder
+	}
+	if encoding, ok := g.operationEncodings[operation]; ok && encoding == FormEncoding {
+		return FormBodyEncoder
+	}
+	return JSONBodyEncoder
+}
+
+// expandPath replaces the {name} placeholders of a path template with the escaped parameters.
+func expandPath(template string, params map[string]string) (string, error) {
+	var path strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			path.WriteString(template)
+			return path.String(), nil
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated placeholder in path %q", template)
+		}
+		name := template[start+1 : start+end]
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("missing path parameter %q", name)
+		}
+		path.WriteString(template[:start])
+		path.WriteString(escapePathSegment(value))
+		template = template[start+end+1:]
+	}
+}
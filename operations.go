@@ -0,0 +1,41 @@
+package gozaya
+
+import (
+	"context"
+)
+
+// Operation identifies an API operation of the client
+type Operation string
+
+// Operations of the client, used to configure per-operation behavior
+const (
+	OperationCreateLink         Operation = "create_link"
+	OperationGetLink            Operation = "get_link"
+	OperationListLinks          Operation = "list_links"
+	OperationUpdateLink         Operation = "update_link"
+	OperationRemoveLink         Operation = "remove_link"
+	OperationListDomains        Operation = "list_domains"
+	OperationListSpaces         Operation = "list_spaces"
+	OperationListPixels         Operation = "list_pixels"
+	OperationGetAccountDefaults Operation = "get_account_defaults"
+	OperationGetPlan            Operation = "get_plan"
+	OperationGetLinkStats       Operation = "get_link_stats"
+	OperationLogin              Operation = "login"
+	OperationRefreshToken       Operation = "refresh_token"
+	// OperationRaw is the operation of the calls made through Do.
+	OperationRaw Operation = "raw"
+)
+
+type operationContextKey struct{}
+
+// withOperation returns a context telling the operation of the requests made with it.
+func withOperation(ctx context.Context, operation Operation) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, operation)
+}
+
+// OperationFromContext returns the operation a request belongs to, e.g. from the context
+// of the request in an http.RoundTripper. It is empty for requests made outside the client.
+func OperationFromContext(ctx context.Context) Operation {
+	operation, _ := ctx.Value(operationContextKey{}).(Operation)
+	return operation
+}
@@ -0,0 +1,64 @@
+package gozaya
+
+import (
+	"maps"
+	"strings"
+)
+
+// WithBaseURL sets the base URL of the API, validated and normalized like the base path
+// of NewClient. Encodings negotiated with the previous base URL are forgotten.
+func WithBaseURL(basePath string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.setBasePath(basePath)
+		g.negotiated = &negotiatedEncodings{}
+	}
+}
+
+// WithHeader sets a header sent with every request
+func WithHeader(key, value string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		// copy the map, which is shared with the parent of a derived client
+		headers := maps.Clone(g.headers)
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers[key] = value
+		g.headers = headers
+	}
+}
+
+// WithHeaders sets headers sent with every request, in addition to those already set
+func WithHeaders(headers map[string]string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		merged := maps.Clone(g.headers)
+		if merged == nil {
+			merged = make(map[string]string, len(headers))
+		}
+		maps.Copy(merged, headers)
+		g.headers = merged
+	}
+}
+
+// WithUserAgent sets the User-Agent sent with every request, "go-zaya/<version> (go <go version>)"
+// by default
+func WithUserAgent(userAgent string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.userAgent = userAgent
+	}
+}
+
+// WithApplication appends the identifier of the application, e.g. "link-importer/2.1", to
+// the User-Agent sent with every request, so that the API support can tell its requests apart.
+func WithApplication(application string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if application = strings.TrimSpace(application); application != "" {
+			g.userAgent = strings.TrimSpace(g.userAgent + " " + application)
+		}
+	}
+}
+
+// WithCredential sets the credential used by the calls made without an explicit token.
+// Unlike WithToken, the caller keeps the credential and can destroy it to clear the token.
+func WithCredential(credential *Credential) func(*GoZaya) {
+	return WithTokenProvider(StaticCredential(credential))
+}
@@ -0,0 +1,109 @@
+package gozaya
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithOpenTelemetry records a span per call to the API with the tracers of provider, or of the
+// global tracer provider when nil. The spans are named after the operation, e.g.
+// "zaya get_link", cover the retries of the call, and are propagated to the API unless
+// WithoutTracing is set. They are independent of the OpenTracing propagation of the
+// gozaya_opentracing build.
+func WithOpenTelemetry(provider trace.TracerProvider) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if provider == nil {
+			provider = otel.GetTracerProvider()
+		}
+		g.tracer = provider.Tracer(modulePath, trace.WithInstrumentationVersion(Version))
+	}
+}
+
+// operationEndpoints maps the operations to the name of their field in the Endpoints
+var operationEndpoints = map[Operation]string{
+	OperationCreateLink:         "CreateLinkEndpoint",
+	OperationGetLink:            "GetLinkEndpoint",
+	OperationListLinks:          "ListLinksEndpoint",
+	OperationUpdateLink:         "UpdateLinkEndpoint",
+	OperationRemoveLink:         "RemoveLinkEndpoint",
+	OperationListDomains:        "ListDomainsEndpoint",
+	OperationListSpaces:         "ListSpacesEndpoint",
+	OperationListPixels:         "ListPixelsEndpoint",
+	OperationGetAccountDefaults: "AccountEndpoint",
+	OperationGetPlan:            "PlanEndpoint",
+	OperationGetLinkStats:       "StatsEndpoint",
+	OperationLogin:              "LoginEndpoint",
+	OperationRefreshToken:       "RefreshTokenEndpoint",
+}
+
+// urlTemplate returns the path template of the endpoint of an operation, e.g.
+// "/api/v1/links/{id}", so that spans of the same endpoint group together. It is empty for
+// the operations without an endpoint of their own, such as the raw calls.
+func (g *GoZaya) urlTemplate(operation Operation) string {
+	name, ok := operationEndpoints[operation]
+	if !ok {
+		return ""
+	}
+	for _, spec := range g.endpointSpecs() {
+		if spec.name != name {
+			continue
+		}
+		if spec.takeID && !strings.Contains(*spec.value, idPlaceholder) {
+			return urlSeparator + *spec.value + urlSeparator + idPlaceholder
+		}
+		return urlSeparator + *spec.value
+	}
+	return ""
+}
+
+// sendTraced sends a request within a span of the call when tracing with OpenTelemetry.
+func (g *GoZaya) sendTraced(ctx context.Context, req *resty.Request, method string, path string, errMessage string) (*resty.Response, error) {
+	operation := OperationFromContext(ctx)
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", method),
+		attribute.String("zaya.operation", string(operation)),
+	}
+	if template := g.urlTemplate(operation); template != "" {
+		attrs = append(attrs, attribute.String("url.template", template))
+	}
+	if id := CorrelationIDFromContext(ctx); id != "" {
+		attrs = append(attrs, attribute.String("zaya.correlation_id", id))
+	}
+	ctx, span := g.tracer.Start(ctx, "zaya "+string(operation), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	defer span.End()
+	if g.propagatesTrace(ctx) {
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	}
+
+	resp, err := g.sendAttempts(ctx, req, method, path, errMessage)
+	if resp != nil && resp.StatusCode() != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode()))
+		if id := requestID(resp.Header()); id != "" {
+			span.SetAttributes(attribute.String("zaya.request_id", id))
+		}
+	}
+	if err != nil {
+		errType := "client"
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			errType = string(apiErr.Type)
+			if apiErr.Code != 0 && apiErr.Type == APIErrTypeUnknown {
+				errType = strconv.Itoa(apiErr.Code)
+			}
+			span.SetAttributes(attribute.Int("zaya.attempts", apiErr.Attempts))
+		}
+		span.SetAttributes(attribute.String("error.type", errType))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return resp, err
+}
@@ -0,0 +1,239 @@
+package gozaya
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"time"
+)
+
+// Page holds the pagination metadata of a list response
+type Page struct {
+	Total       int
+	PerPage     int
+	CurrentPage int
+	LastPage    int
+	// NextPageURL is the URL of the next page, empty on the last page.
+	NextPageURL string
+}
+
+// HasNext reports whether there is a page after this one
+func (p *Page) HasNext() bool {
+	if p == nil {
+		return false
+	}
+	if p.NextPageURL != "" {
+		return true
+	}
+	return p.CurrentPage < p.LastPage
+}
+
+// pageLinks is the "links" object of the pagination envelope
+type pageLinks struct {
+	First string  `json:"first"`
+	Last  string  `json:"last"`
+	Prev  *string `json:"prev"`
+	Next  *string `json:"next"`
+}
+
+// pageMeta is the "meta" object of the pagination envelope
+type pageMeta struct {
+	CurrentPage flexInt         `json:"current_page"`
+	From        flexInt         `json:"from"`
+	LastPage    flexInt         `json:"last_page"`
+	Links       json.RawMessage `json:"links"`
+	Path        string          `json:"path"`
+	PerPage     flexInt         `json:"per_page"`
+	To          flexInt         `json:"to"`
+	Total       flexInt         `json:"total"`
+}
+
+// newPage builds the pagination metadata from the envelope
+func newPage(links pageLinks, meta pageMeta) *Page {
+	return &Page{
+		Total:       int(meta.Total),
+		PerPage:     int(meta.PerPage),
+		CurrentPage: int(meta.CurrentPage),
+		LastPage:    int(meta.LastPage),
+		NextPageURL: PString(links.Next),
+	}
+}
+
+// IterateLinks returns an iterator over all the links matching params, fetching the
+// following pages on demand. Iteration starts at params.Page, or at the first page when
+// it is not set, and stops at the first error, which is yielded with a nil link. When ctx
+// is canceled or times out, the yielded error is ctx.Err().
+func (g *GoZaya) IterateLinks(ctx context.Context, token string, params GetLinksParams) iter.Seq2[*Link, error] {
+	return func(yield func(*Link, error) bool) {
+		page := PInt(params.Page)
+		if page < 1 {
+			page = 1
+		}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			params.Page = IntP(page)
+			links, meta, err := g.ListLinks(ctx, token, params)
+			if err != nil {
+				// report the cancellation rather than the failed call it caused
+				if ctx.Err() != nil {
+					err = ctx.Err()
+				}
+				yield(nil, err)
+				return
+			}
+
+			for _, link := range links {
+				if !yield(link, nil) {
+					return
+				}
+			}
+
+			if len(links) == 0 || !meta.HasNext() {
+				return
+			}
+			page++
+		}
+	}
+}
+
+// listPage fetches a page of a list endpoint.
+func listPage[T any](ctx context.Context, g *GoZaya, token string, endpointName string, endpoint string, params interface{}, operation string) ([]T, *Page, error) {
+	var result listResponse[T]
+
+	path, err := endpointPath(endpointName, endpoint, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to %s: %w", operation, err)
+	}
+
+	queryParams, err := GetQueryParams(params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build %s query: %w", operation, err)
+	}
+
+	resp, err := g.get(ctx, token, path, queryParams, "failed to "+operation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := g.decodeResponse(resp, &result, "failed to parse "+operation+" response"); err != nil {
+		return nil, nil, err
+	}
+
+	return result.Data, newPage(result.Links, result.Meta), nil
+}
+
+// ListDomains returns a page of the branded domains of the account.
+func (g *GoZaya) ListDomains(ctx context.Context, token string, params ListParams) ([]*Domain, *Page, error) {
+	return listPage[*Domain](withOperation(ctx, OperationListDomains), g, token, "ListDomainsEndpoint", g.Config.ListDomainsEndpoint, params, "list domains")
+}
+
+// ListSpaces returns a page of the spaces of the account.
+func (g *GoZaya) ListSpaces(ctx context.Context, token string, params ListParams) ([]*Space, *Page, error) {
+	return listPage[*Space](withOperation(ctx, OperationListSpaces), g, token, "ListSpacesEndpoint", g.Config.ListSpacesEndpoint, params, "list spaces")
+}
+
+// ListPixels returns a page of the pixels of the account.
+func (g *GoZaya) ListPixels(ctx context.Context, token string, params ListParams) ([]*Pixel, *Page, error) {
+	return listPage[*Pixel](withOperation(ctx, OperationListPixels), g, token, "ListPixelsEndpoint", g.Config.ListPixelsEndpoint, params, "list pixels")
+}
+
+const (
+	// listAllPageSize is the page size used by the ListAll helpers, the maximum accepted by the API.
+	listAllPageSize = 100
+	// listAllMaxRateLimitRetries bounds the consecutive 429 responses tolerated by the ListAll helpers.
+	listAllMaxRateLimitRetries = 5
+	// listAllDefaultRateLimitWait is the wait after a 429 response without Retry-After.
+	listAllDefaultRateLimitWait = time.Second
+)
+
+// listAll drains the pages returned by fetch into a slice. It stops after maxResults
+// items when maxResults is positive, and waits before retrying when rate limited.
+func listAll[T any](ctx context.Context, maxResults int, fetch func(page int) ([]T, *Page, error)) ([]T, error) {
+	var (
+		items       []T
+		rateLimited int
+	)
+
+	for page := 1; ; {
+		batch, meta, err := fetch(page)
+		if err != nil {
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != http.StatusTooManyRequests || rateLimited >= listAllMaxRateLimitRetries {
+				return nil, err
+			}
+			rateLimited++
+
+			wait := apiErr.RetryAfter
+			if wait <= 0 {
+				wait = listAllDefaultRateLimitWait << (rateLimited - 1)
+			}
+			if err := sleepContext(ctx, wait); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		rateLimited = 0
+
+		items = append(items, batch...)
+		if maxResults > 0 && len(items) >= maxResults {
+			return items[:maxResults], nil
+		}
+		if len(batch) == 0 || !meta.HasNext() {
+			return items, nil
+		}
+		page++
+	}
+}
+
+// pageSize returns the requested page size, or the ListAll default when not set.
+func pageSize(perPage *int) *int {
+	if perPage != nil {
+		return perPage
+	}
+	return IntP(listAllPageSize)
+}
+
+// ListAllLinks returns all the links matching params, up to maxResults links when
+// maxResults is positive. Rate limited requests are retried after the delay requested by the API.
+func (g *GoZaya) ListAllLinks(ctx context.Context, token string, params GetLinksParams, maxResults int) ([]*Link, error) {
+	params.PerPage = pageSize(params.PerPage)
+	return listAll(ctx, maxResults, func(page int) ([]*Link, *Page, error) {
+		params.Page = IntP(page)
+		return g.ListLinks(ctx, token, params)
+	})
+}
+
+// ListAllDomains returns all the branded domains, up to maxResults domains when maxResults is positive.
+func (g *GoZaya) ListAllDomains(ctx context.Context, token string, params ListParams, maxResults int) ([]*Domain, error) {
+	params.PerPage = pageSize(params.PerPage)
+	return listAll(ctx, maxResults, func(page int) ([]*Domain, *Page, error) {
+		params.Page = IntP(page)
+		return g.ListDomains(ctx, token, params)
+	})
+}
+
+// ListAllSpaces returns all the spaces, up to maxResults spaces when maxResults is positive.
+func (g *GoZaya) ListAllSpaces(ctx context.Context, token string, params ListParams, maxResults int) ([]*Space, error) {
+	params.PerPage = pageSize(params.PerPage)
+	return listAll(ctx, maxResults, func(page int) ([]*Space, *Page, error) {
+		params.Page = IntP(page)
+		return g.ListSpaces(ctx, token, params)
+	})
+}
+
+// ListAllPixels returns all the pixels, up to maxResults pixels when maxResults is positive.
+func (g *GoZaya) ListAllPixels(ctx context.Context, token string, params ListParams, maxResults int) ([]*Pixel, error) {
+	params.PerPage = pageSize(params.PerPage)
+	return listAll(ctx, maxResults, func(page int) ([]*Pixel, *Page, error) {
+		params.Page = IntP(page)
+		return g.ListPixels(ctx, token, params)
+	})
+}
@@ -0,0 +1,163 @@
+package gozaya
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Plan holds the plan of an account along with its usage for the current billing period.
+// Limits are 0 or less, usually -1, when unlimited.
+type Plan struct {
+	Name        string     `json:"name"`
+	LinksLimit  int        `json:"links_limit"`
+	LinksUsed   int        `json:"links_used"`
+	ClicksLimit int        `json:"clicks_limit"`
+	ClicksUsed  int        `json:"clicks_used"`
+	ResetsAt    *time.Time `json:"resets_at"`
+}
+
+// LinksRemaining returns the number of links that can still be created in the billing
+// period, -1 when unlimited
+func (p *Plan) LinksRemaining() int {
+	if p.LinksLimit <= 0 {
+		return -1
+	}
+	return max(p.LinksLimit-p.LinksUsed, 0)
+}
+
+// linksUsage returns the share of the link quota used, 0 when unlimited
+func (p *Plan) linksUsage() float64 {
+	if p.LinksLimit <= 0 {
+		return 0
+	}
+	return float64(p.LinksUsed) / float64(p.LinksLimit)
+}
+
+type planResponse struct {
+	Data *Plan `json:"data" required:"true"`
+}
+
+// GetPlan returns the plan of the account and its usage
+func (g *GoZaya) GetPlan(ctx context.Context, token string) (*Plan, error) {
+	var result planResponse
+
+	path, err := endpointPath("PlanEndpoint", g.Config.PlanEndpoint, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+
+	ctx = withOperation(ctx, OperationGetPlan)
+	resp, err := g.get(ctx, token, path, nil, "failed to get plan")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.decodeResponse(resp, &result, "failed to parse plan response"); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// ErrQuotaExhausted is matched by the errors returned when the quota guard blocks a creation
+var ErrQuotaExhausted = errors.New("link quota is nearly exhausted")
+
+// QuotaError is returned when the quota guard blocks the creation of a link
+type QuotaError struct {
+	Plan *Plan
+}
+
+// Error stringifies the QuotaError
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("link quota of plan %q is nearly exhausted: %d of %d links used", e.Plan.Name, e.Plan.LinksUsed, e.Plan.LinksLimit)
+}
+
+// Unwrap allows matching the error with ErrQuotaExhausted
+func (e *QuotaError) Unwrap() error {
+	return ErrQuotaExhausted
+}
+
+// QuotaGuard configures WithQuotaGuard
+type QuotaGuard struct {
+	// Threshold is the share of the link quota, between 0 and 1, from which the guard
+	// triggers. It defaults to 0.9.
+	Threshold float64
+	// OnWarning is called before creating a link once the threshold is reached.
+	OnWarning func(ctx context.Context, plan *Plan)
+	// Block rejects the creations with a *QuotaError once the threshold is reached.
+	Block bool
+	// TTL is how long the plan is cached. It defaults to one minute.
+	TTL time.Duration
+}
+
+// quotaGuard is a QuotaGuard with the plans it caches per token
+type quotaGuard struct {
+	QuotaGuard
+	plans *tokenCache[*Plan]
+}
+
+const (
+	defaultQuotaThreshold = 0.9
+	defaultQuotaTTL       = time.Minute
+)
+
+// WithQuotaGuard checks the link quota of the account before creating links, warning or
+// blocking once it is nearly exhausted. The cached usage is counted up locally as links are
+// created. When the plan cannot be fetched, the links are created unguarded; the failure is
+// cached for up to 5 seconds before the plan is fetched again.
+func WithQuotaGuard(guard QuotaGuard) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if guard.Threshold <= 0 {
+			guard.Threshold = defaultQuotaThreshold
+		}
+		if guard.TTL <= 0 {
+			guard.TTL = defaultQuotaTTL
+		}
+		plans := newTokenCache[*Plan](guard.TTL)
+		plans.clone = func(plan *Plan) *Plan {
+			if plan == nil {
+				return nil
+			}
+			copied := *plan
+			return &copied
+		}
+		g.quotaGuard = &quotaGuard{QuotaGuard: guard, plans: plans}
+	}
+}
+
+// checkQuota applies the quota guard before a link is created.
+func (g *GoZaya) checkQuota(ctx context.Context, token string) error {
+	if g.quotaGuard == nil {
+		return nil
+	}
+	plan, err := g.quotaGuard.plans.get(ctx, g, token, g.GetPlan)
+	if err != nil || plan == nil {
+		return nil
+	}
+
+	if plan.linksUsage() < g.quotaGuard.Threshold {
+		return nil
+	}
+	if g.quotaGuard.OnWarning != nil {
+		g.quotaGuard.OnWarning(ctx, plan)
+	}
+	if g.quotaGuard.Block {
+		return &QuotaError{Plan: plan}
+	}
+	return nil
+}
+
+// countCreatedLink counts a created link in the cached usage of the token.
+func (g *GoZaya) countCreatedLink(ctx context.Context, token string) {
+	if g.quotaGuard == nil {
+		return
+	}
+	g.quotaGuard.plans.update(ctx, g, token, func(plan *Plan) *Plan {
+		if plan != nil {
+			plan.LinksUsed++
+		}
+		return plan
+	})
+}
@@ -0,0 +1,286 @@
+package gozaya
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// LinkChangeType is the kind of change detected on a link
+type LinkChangeType string
+
+const (
+	// LinkCreated is emitted for links created after the last poll.
+	LinkCreated LinkChangeType = "created"
+	// LinkUpdated is emitted for links whose updated_at moved since the last poll.
+	LinkUpdated LinkChangeType = "updated"
+	// LinkDeleted is emitted for links that disappeared since the last poll.
+	LinkDeleted LinkChangeType = "deleted"
+)
+
+// pollPageSize is the page size used when walking the links while polling.
+const pollPageSize = 100
+
+// LinkChangeEvent describes a change detected on a link while polling
+type LinkChangeEvent struct {
+	Type   LinkChangeType
+	LinkID int64
+	// Link holds the current state of the link. It is nil for deleted links.
+	Link *Link
+}
+
+// LinkChangeStream is the stream of link changes returned by PollChanges.
+// The stream owns a polling goroutine, which stops when the context passed to
+// PollChanges is done, when Close is called, or when polling fails permanently.
+type LinkChangeStream struct {
+	events chan LinkChangeEvent
+	errs   chan error
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// Events returns the channel of link changes. It is closed when the stream stops.
+func (s *LinkChangeStream) Events() <-chan LinkChangeEvent {
+	return s.events
+}
+
+// Errors returns the channel of transient polling errors, after which polling continues.
+// Errors are dropped when the channel is not drained. It is closed when the stream stops.
+func (s *LinkChangeStream) Errors() <-chan error {
+	return s.errs
+}
+
+// Close stops the stream and waits for the polling goroutine to exit
+func (s *LinkChangeStream) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// Done returns a channel closed once the stream stopped
+func (s *LinkChangeStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns why the stream stopped once Events is closed: the error of the context of
+// PollChanges when it was canceled or timed out, the permanent polling error when the API
+// rejected the calls or the invalid interval, or nil when the stream was closed with Close.
+// It returns nil while the stream is running.
+func (s *LinkChangeStream) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// PollChanges polls the links of the account every interval and emits an event for
+// every link created, updated or deleted since the previous poll, giving webhook-like
+// behavior over plain polling. Links created or updated after since are reported by the
+// first poll. Transient polling errors are reported on the Errors channel and polling
+// continues; authentication and authorization failures stop the stream.
+//
+// The first poll walks all the links. The following ones only list the links updated since
+// the last change seen, with GetLinksParams.UpdatedSince, and count the links to detect the
+// deletions, walking all the links again only when the count does not match.
+//
+// When interval is not positive, the returned stream is already stopped and Err reports it.
+func (g *GoZaya) PollChanges(ctx context.Context, token string, since time.Time, interval time.Duration) *LinkChangeStream {
+	pollCtx, cancel := context.WithCancel(ctx)
+	stream := &LinkChangeStream{
+		events: make(chan LinkChangeEvent),
+		errs:   make(chan error, 1),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	if interval <= 0 {
+		cancel()
+		stream.err = fmt.Errorf("failed to poll link changes: interval %s is not positive", interval)
+		close(stream.events)
+		close(stream.errs)
+		close(stream.done)
+		return stream
+	}
+
+	go func() {
+		defer close(stream.done)
+		defer close(stream.events)
+		defer close(stream.errs)
+		defer cancel()
+
+		stream.err = g.poll(pollCtx, token, since, interval, stream)
+		// a cancellation of the parent context is reported, a call to Close is not
+		if stream.err != nil && pollCtx.Err() != nil {
+			stream.err = ctx.Err()
+		}
+	}()
+
+	return stream
+}
+
+// linkPoller holds the links known from the previous polls.
+type linkPoller struct {
+	g     *GoZaya
+	token string
+	since time.Time
+	// known maps the IDs of the links to their updated_at, nil before the first poll
+	known map[int64]time.Time
+	// cursor is the latest updated_at seen, from which the next poll lists the changes
+	cursor time.Time
+}
+
+// poll runs the polling loop until ctx is done or polling fails permanently.
+func (g *GoZaya) poll(ctx context.Context, token string, since time.Time, interval time.Duration, stream *LinkChangeStream) error {
+	poller := &linkPoller{g: g, token: token, since: since, cursor: since}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := poller.next(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil && permanentPollError(err):
+			return err
+		case err != nil:
+			select {
+			case stream.errs <- err:
+			default:
+			}
+		default:
+			for _, event := range events {
+				select {
+				case stream.events <- event:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// permanentPollError reports whether retrying a poll cannot succeed.
+func permanentPollError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Code == http.StatusUnauthorized || apiErr.Code == http.StatusForbidden
+}
+
+// next polls the changes since the previous poll, walking all the links on the first poll or
+// when links were deleted. The known links are only updated when the poll succeeds.
+func (p *linkPoller) next(ctx context.Context) ([]LinkChangeEvent, error) {
+	if p.known == nil {
+		return p.resync(ctx)
+	}
+
+	changed := make(map[int64]*Link)
+	params := GetLinksParams{PerPage: IntP(pollPageSize), UpdatedSince: StringP(p.cursor.UTC().Format(time.RFC3339))}
+	for link, err := range p.g.IterateLinks(ctx, p.token, params) {
+		if err != nil {
+			return nil, err
+		}
+		changed[link.ID] = link
+	}
+	_, page, err := p.g.ListLinks(ctx, p.token, GetLinksParams{PerPage: IntP(1)})
+	if err != nil {
+		return nil, err
+	}
+
+	created := 0
+	for id := range changed {
+		if _, ok := p.known[id]; !ok {
+			created++
+		}
+	}
+	if page == nil || page.Total != len(p.known)+created {
+		// links were deleted, which only the walk of all the links tells
+		return p.resync(ctx)
+	}
+
+	var events []LinkChangeEvent
+	for id, link := range changed {
+		updatedAt, ok := p.known[id]
+		switch {
+		case !ok:
+			events = append(events, LinkChangeEvent{Type: LinkCreated, LinkID: id, Link: link})
+		case link.UpdatedAt.After(updatedAt):
+			events = append(events, LinkChangeEvent{Type: LinkUpdated, LinkID: id, Link: link})
+		}
+		p.remember(id, link)
+	}
+	return events, nil
+}
+
+// resync walks all the links and diffs them with the known ones.
+func (p *linkPoller) resync(ctx context.Context) ([]LinkChangeEvent, error) {
+	current, err := p.g.snapshotLinks(ctx, p.token)
+	if err != nil {
+		return nil, err
+	}
+	events := diffLinks(p.known, current, p.since)
+	p.known = make(map[int64]time.Time, len(current))
+	for id, link := range current {
+		p.remember(id, link)
+	}
+	return events, nil
+}
+
+// remember records the updated_at of a link and moves the cursor past it.
+func (p *linkPoller) remember(id int64, link *Link) {
+	p.known[id] = link.UpdatedAt
+	if link.UpdatedAt.After(p.cursor) {
+		p.cursor = link.UpdatedAt
+	}
+}
+
+// snapshotLinks walks all the pages of links and indexes them by ID.
+func (g *GoZaya) snapshotLinks(ctx context.Context, token string) (map[int64]*Link, error) {
+	links := make(map[int64]*Link)
+	for link, err := range g.IterateLinks(ctx, token, GetLinksParams{PerPage: IntP(pollPageSize)}) {
+		if err != nil {
+			return nil, err
+		}
+		links[link.ID] = link
+	}
+	return links, nil
+}
+
+// diffLinks compares the links known from the previous poll with the current ones.
+// When there is no previous poll, the links are compared against since.
+func diffLinks(known map[int64]time.Time, current map[int64]*Link, since time.Time) []LinkChangeEvent {
+	var events []LinkChangeEvent
+
+	for id, link := range current {
+		updatedAt, ok := known[id]
+		switch {
+		case known == nil && link.CreatedAt.After(since):
+			events = append(events, LinkChangeEvent{Type: LinkCreated, LinkID: id, Link: link})
+		case known == nil && link.UpdatedAt.After(since):
+			events = append(events, LinkChangeEvent{Type: LinkUpdated, LinkID: id, Link: link})
+		case known != nil && !ok:
+			events = append(events, LinkChangeEvent{Type: LinkCreated, LinkID: id, Link: link})
+		case ok && link.UpdatedAt.After(updatedAt):
+			events = append(events, LinkChangeEvent{Type: LinkUpdated, LinkID: id, Link: link})
+		}
+	}
+
+	for id := range known {
+		if _, ok := current[id]; !ok {
+			events = append(events, LinkChangeEvent{Type: LinkDeleted, LinkID: id})
+		}
+	}
+
+	return events
+}
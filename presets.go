@@ -0,0 +1,57 @@
+package gozaya
+
+import (
+	"time"
+)
+
+// preset bundles options into one
+func preset(options ...Option) Option {
+	return func(g *GoZaya) {
+		for _, option := range options {
+			option(g)
+		}
+	}
+}
+
+// PresetInteractive tunes the client for calls made while a user waits, e.g. resolving a link
+// in a redirect path: short timeouts, a single quick retry and no waiting on rate limits beyond
+// a second. The presets only tune the calls, so they can also be given to Apply; the options
+// following a preset override its settings:
+//
+//	client := NewClient(basePath, PresetInteractive(), WithTimeout(time.Second))
+func PresetInteractive() Option {
+	return preset(
+		WithTimeout(2*time.Second),
+		WithRetry(2, 50*time.Millisecond, 250*time.Millisecond),
+		WithRetryMaxElapsed(3*time.Second),
+		WithRateLimitRetry(1, time.Second),
+	)
+}
+
+// PresetBatch tunes the client for background jobs that favor completion over latency: long
+// timeouts, patient retries within five minutes, waiting out rate limits of up to two minutes,
+// and a few requests in flight to stay clear of the abuse protection of the API.
+func PresetBatch() Option {
+	return preset(
+		WithTimeout(60*time.Second),
+		WithRetry(5, 500*time.Millisecond, 30*time.Second),
+		WithRetryMaxElapsed(5*time.Minute),
+		WithRateLimitRetry(10, 2*time.Minute),
+		WithMaxConcurrency(4),
+	)
+}
+
+// PresetHighThroughput tunes the client for services making many concurrent calls: moderate
+// timeouts, up to 32 requests in flight, and retries drawn from a budget, so that retries stop
+// when more than about one attempt in ten fails instead of amplifying an outage. Every call of
+// PresetHighThroughput creates a new budget; use WithRetryBudget to share one across clients.
+func PresetHighThroughput() Option {
+	return preset(
+		WithTimeout(10*time.Second),
+		WithRetry(3, 100*time.Millisecond, 2*time.Second),
+		WithRetryBudget(NewRetryBudget(100, 0.1)),
+		WithRetryMaxElapsed(15*time.Second),
+		WithRateLimitRetry(3, 10*time.Second),
+		WithMaxConcurrency(32),
+	)
+}
@@ -0,0 +1,164 @@
+package gozaya
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// ProbeResult is the outcome of probing the redirect of a short link
+type ProbeResult struct {
+	URL        string
+	StatusCode int
+	// Location is the redirect target returned by the edge.
+	Location string
+	Latency  time.Duration
+	Err      error
+	At       time.Time
+}
+
+// Healthy reports whether the short link redirected within the latency threshold.
+// A threshold of 0 disables the latency check.
+func (r ProbeResult) Healthy(threshold time.Duration) bool {
+	if r.Err != nil || r.StatusCode < 300 || r.StatusCode >= 400 {
+		return false
+	}
+	return threshold <= 0 || r.Latency <= threshold
+}
+
+// ProberConfig configures a Prober
+type ProberConfig struct {
+	// URLs are the short links to probe.
+	URLs []string
+	// Targets, when set, is called every round to get the short links to probe instead of URLs.
+	Targets func(ctx context.Context) ([]string, error)
+	// SampleSize is the number of short links probed every round. All of them are probed when 0.
+	SampleSize int
+	// Interval is the delay between two rounds. It defaults to one minute.
+	Interval time.Duration
+	// Timeout bounds every probe. It defaults to ten seconds.
+	Timeout time.Duration
+	// HTTPClient is used to send the probes. Redirects are never followed.
+	HTTPClient *http.Client
+	// LatencyThreshold is the redirect latency above which a probe is considered degraded.
+	LatencyThreshold time.Duration
+	// OnResult is called with every probe result, e.g. to record metrics.
+	OnResult func(ctx context.Context, result ProbeResult)
+	// OnAlert is called for every failed, non-redirecting or slow probe.
+	OnAlert func(ctx context.Context, result ProbeResult)
+	// OnError is called when Targets fails.
+	OnError func(ctx context.Context, err error)
+}
+
+// Prober periodically sends HEAD requests to short links, exercising the end-user redirect
+// path rather than the API, and reports the redirect latency and status.
+type Prober struct {
+	config ProberConfig
+	client *http.Client
+}
+
+// NewProber creates a prober
+func NewProber(config ProberConfig) *Prober {
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	client := &http.Client{}
+	if config.HTTPClient != nil {
+		*client = *config.HTTPClient
+	}
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &Prober{config: config, client: client}
+}
+
+// Run probes the short links every interval until ctx is done
+func (p *Prober) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.ProbeOnce(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ProbeOnce probes a sample of the short links and returns the results
+func (p *Prober) ProbeOnce(ctx context.Context) []ProbeResult {
+	urls := p.config.URLs
+	if p.config.Targets != nil {
+		targets, err := p.config.Targets(ctx)
+		if err != nil {
+			if p.config.OnError != nil {
+				p.config.OnError(ctx, err)
+			}
+			return nil
+		}
+		urls = targets
+	}
+
+	results := make([]ProbeResult, 0, len(urls))
+	for _, url := range sample(urls, p.config.SampleSize) {
+		if ctx.Err() != nil {
+			break
+		}
+		result := p.probe(ctx, url)
+		results = append(results, result)
+
+		if p.config.OnResult != nil {
+			p.config.OnResult(ctx, result)
+		}
+		if p.config.OnAlert != nil && !result.Healthy(p.config.LatencyThreshold) {
+			p.config.OnAlert(ctx, result)
+		}
+	}
+	return results
+}
+
+func (p *Prober) probe(ctx context.Context, url string) ProbeResult {
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+
+	result := ProbeResult{URL: url, At: time.Now()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	req.Header.Set("User-Agent", defaultUserAgent()+" prober")
+
+	resp, err := p.client.Do(req)
+	result.Latency = time.Since(result.At)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	result.Location = resp.Header.Get("Location")
+	return result
+}
+
+// sample returns up to n items picked at random, or all the items when n is 0.
+func sample(items []string, n int) []string {
+	if n <= 0 || n >= len(items) {
+		return items
+	}
+	picked := make([]string, 0, n)
+	for _, i := range rand.Perm(len(items))[:n] {
+		picked = append(picked, items[i])
+	}
+	return picked
+}
@@ -0,0 +1,159 @@
+package gozaya
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ProblemContentType is the content type of the bodies written by WriteError (RFC 9457)
+const ProblemContentType = "application/problem+json"
+
+// Problem is a problem details object (RFC 9457) describing an error of the client
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Code identifies the kind of error, e.g. "rate_limited" or "alias_forbidden"
+	Code string `json:"code"`
+	// RetryAfter is the number of seconds to wait before retrying, 0 when not known
+	RetryAfter int `json:"retry_after,omitempty"`
+	// Errors maps the rejected fields of a request to their messages
+	Errors map[string][]string `json:"errors,omitempty"`
+}
+
+// Codes of the problems built by NewProblem
+const (
+	ProblemCodeInvalidRequest  = "invalid_request"
+	ProblemCodeValidation      = "validation_failed"
+	ProblemCodeNotFound        = "not_found"
+	ProblemCodeConflict        = "conflict"
+	ProblemCodeAliasForbidden  = "alias_forbidden"
+	ProblemCodePolicyViolation = "policy_violation"
+	ProblemCodePlanRestriction = "plan_restriction"
+	ProblemCodeQuotaExhausted  = "quota_exhausted"
+	ProblemCodeRateLimited     = "rate_limited"
+	ProblemCodeUnavailable     = "unavailable"
+	ProblemCodeTimeout         = "timeout"
+	ProblemCodeCanceled        = "canceled"
+	ProblemCodeUpstream        = "upstream_error"
+	ProblemCodeInternal        = "internal_error"
+)
+
+// statusClientClosedRequest is the non-standard status of requests abandoned by the caller
+const statusClientClosedRequest = 499
+
+// NewProblem maps an error returned by the client to the problem a service proxying the API
+// should report to its own callers:
+//   - the errors caused by the request, such as 400, 404 and 422 responses, alias, link
+//     policy and plan violations, keep a 4xx status and their message as detail, and the rejected
+//     fields of a *ValidationError are listed in errors with the status of its response;
+//   - 429 responses and the quota guard report 429 with the delay to wait, when known;
+//   - the failures of the API, including 401 and 403 responses that mean the credentials
+//     of the service are wrong rather than those of its caller, report 502, 503 or 504
+//     without detail, so that nothing about the upstream leaks;
+//   - other errors report 500 without detail.
+func NewProblem(err error) *Problem {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		apiErr    *APIError
+		validErr  *ValidationError
+		rateErr   *RateLimitError
+		quotaErr  *QuotaError
+		problem   *Problem
+		retryWait time.Duration
+	)
+	switch {
+	case errors.Is(err, context.Canceled):
+		problem = newProblem(statusClientClosedRequest, ProblemCodeCanceled, "")
+	case errors.Is(err, context.DeadlineExceeded):
+		problem = newProblem(http.StatusGatewayTimeout, ProblemCodeTimeout, "")
+	case errors.Is(err, ErrAliasForbidden):
+		problem = newProblem(http.StatusUnprocessableEntity, ProblemCodeAliasForbidden, err.Error())
+	case errors.Is(err, ErrPolicyViolation):
+		problem = newProblem(http.StatusUnprocessableEntity, ProblemCodePolicyViolation, err.Error())
+	case errors.Is(err, ErrPlanRestriction):
+		problem = newProblem(http.StatusForbidden, ProblemCodePlanRestriction, err.Error())
+	case errors.As(err, &quotaErr):
+		problem = newProblem(http.StatusTooManyRequests, ProblemCodeQuotaExhausted, err.Error())
+		if quotaErr.Plan != nil && quotaErr.Plan.ResetsAt != nil {
+			retryWait = time.Until(*quotaErr.Plan.ResetsAt)
+		}
+	case errors.As(err, &rateErr):
+		problem = newProblem(http.StatusTooManyRequests, ProblemCodeRateLimited, "")
+		retryWait = rateErr.RetryAfter
+	case errors.As(err, &validErr):
+		status := validErr.Code
+		if status < 400 || status >= 500 {
+			status = http.StatusUnprocessableEntity
+		}
+		problem = newProblem(status, ProblemCodeValidation, validErr.Message)
+		problem.Errors = validErr.FieldErrors
+	case errors.As(err, &apiErr):
+		problem = apiProblem(apiErr)
+		retryWait = apiErr.RetryAfter
+	default:
+		problem = newProblem(http.StatusInternalServerError, ProblemCodeInternal, "")
+	}
+
+	if retryWait > 0 {
+		problem.RetryAfter = int(math.Ceil(retryWait.Seconds()))
+	}
+	return problem
+}
+
+// apiProblem maps the error of an API response.
+func apiProblem(apiErr *APIError) *Problem {
+	switch code := apiErr.Code; {
+	case code == 0:
+		return newProblem(http.StatusBadGateway, ProblemCodeUpstream, "")
+	case code == http.StatusNotFound || code == http.StatusGone:
+		return newProblem(code, ProblemCodeNotFound, apiErr.Message)
+	case code == http.StatusConflict:
+		return newProblem(code, ProblemCodeConflict, apiErr.Message)
+	case code == http.StatusTooManyRequests:
+		return newProblem(code, ProblemCodeRateLimited, "")
+	case code == http.StatusServiceUnavailable:
+		return newProblem(code, ProblemCodeUnavailable, "")
+	case code == http.StatusGatewayTimeout:
+		return newProblem(code, ProblemCodeTimeout, "")
+	case code == http.StatusUnauthorized || code == http.StatusForbidden || code >= 500:
+		return newProblem(http.StatusBadGateway, ProblemCodeUpstream, "")
+	case code >= 400:
+		return newProblem(code, ProblemCodeInvalidRequest, apiErr.Message)
+	default:
+		return newProblem(http.StatusBadGateway, ProblemCodeUpstream, "")
+	}
+}
+
+func newProblem(status int, code string, detail string) *Problem {
+	title := http.StatusText(status)
+	if status == statusClientClosedRequest {
+		title = "Client Closed Request"
+	}
+	return &Problem{Type: "about:blank", Title: title, Status: status, Detail: detail, Code: code}
+}
+
+// WriteError writes the problem of err, as built by NewProblem, to w as application/problem+json,
+// with a Retry-After header when the caller should wait before retrying.
+func WriteError(w http.ResponseWriter, err error) {
+	problem := NewProblem(err)
+	if problem == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	if problem.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(problem.RetryAfter))
+	}
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
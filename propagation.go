@@ -0,0 +1,30 @@
+package gozaya
+
+import (
+	"context"
+)
+
+// WithoutTracing stops propagating the trace of the calls to the API, for the environments
+// where sending trace headers to a third party is not allowed. The spans of WithOpenTelemetry
+// are still recorded. See WithCallTracing to override it per call.
+func WithoutTracing() func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.withoutTracing = true
+	}
+}
+
+type tracingContextKey struct{}
+
+// WithCallTracing returns a context enabling or disabling the propagation of the trace of
+// the calls made with it, whatever WithoutTracing.
+func WithCallTracing(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, tracingContextKey{}, enabled)
+}
+
+// propagatesTrace reports whether the trace of a call is propagated to the API
+func (g *GoZaya) propagatesTrace(ctx context.Context) bool {
+	if enabled, ok := ctx.Value(tracingContextKey{}).(bool); ok {
+		return enabled
+	}
+	return !g.withoutTracing
+}
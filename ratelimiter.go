@@ -0,0 +1,188 @@
+package gozaya
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter bounds the rate of the requests sent by the client.
+// Wait blocks until a request may be sent or ctx is done.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimiter makes the client wait for the rate limiter before sending every request.
+func WithRateLimiter(limiter RateLimiter) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.rateLimiter = limiter })
+	}
+}
+
+// TokenBucket is an in-process RateLimiter allowing rate requests per second on average,
+// with bursts of up to burst requests.
+type TokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a token bucket allowing rate requests per second with bursts of burst
+// requests. A rate that is not positive, or infinite, is unlimited: Wait never blocks. A burst
+// below 1 is raised to 1.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// Wait blocks until a token is available or ctx is done
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if unlimitedRate(b.rate) {
+		return nil
+	}
+	for {
+		wait := b.take(time.Now())
+		if wait == 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// take takes a token and returns 0, or returns how long to wait for the next token.
+func (b *TokenBucket) take(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// unlimitedRate reports whether a rate puts no limit on the requests
+func unlimitedRate(rate float64) bool {
+	return !(rate > 0) || math.IsInf(rate, 1)
+}
+
+// RateLimitStore holds token buckets shared by several processes, so that all the replicas
+// of a service collectively respect the rate limit of the account.
+type RateLimitStore interface {
+	// Take atomically takes a token from the bucket identified by key, refilled at rate tokens
+	// per second up to burst tokens. It returns 0 when a token was taken, or how long to wait
+	// before trying again.
+	Take(ctx context.Context, key string, rate float64, burst int) (time.Duration, error)
+}
+
+// SharedRateLimiter is a RateLimiter backed by a RateLimitStore
+type SharedRateLimiter struct {
+	store RateLimitStore
+	key   string
+	rate  float64
+	burst int
+}
+
+// NewSharedRateLimiter returns a rate limiter taking its tokens from the bucket identified by
+// key in store. Every replica must use the same key, rate and burst. Like with NewTokenBucket,
+// a rate that is not positive, or infinite, is unlimited and a burst below 1 is raised to 1.
+func NewSharedRateLimiter(store RateLimitStore, key string, rate float64, burst int) *SharedRateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &SharedRateLimiter{store: store, key: key, rate: rate, burst: burst}
+}
+
+// Wait blocks until a token is taken from the shared bucket or ctx is done
+func (l *SharedRateLimiter) Wait(ctx context.Context) error {
+	if unlimitedRate(l.rate) {
+		return nil
+	}
+	for {
+		wait, err := l.store.Take(ctx, l.key, l.rate, l.burst)
+		if err != nil {
+			return fmt.Errorf("failed to take rate limit token: %w", err)
+		}
+		if wait <= 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// RedisEvalFunc evaluates a Lua script on a Redis server. With go-redis it can be written as
+//
+//	func(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+//		return rdb.Eval(ctx, script, keys, args...).Result()
+//	}
+type RedisEvalFunc func(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+
+// redisTokenBucketScript takes a token from the bucket stored in the hash KEYS[1],
+// refilled at ARGV[1] tokens per second up to ARGV[2] tokens, using the clock of the
+// Redis server. It returns the number of seconds to wait, 0 when a token was taken.
+const redisTokenBucketScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+else
+  wait = (1 - tokens) / rate
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('EXPIRE', KEYS[1], math.ceil(burst / rate) + 1)
+return tostring(wait)
+`
+
+type redisRateLimitStore struct {
+	eval RedisEvalFunc
+}
+
+// NewRedisRateLimitStore returns a RateLimitStore keeping the buckets in Redis.
+// The buckets are updated atomically by a Lua script and expire when unused.
+func NewRedisRateLimitStore(eval RedisEvalFunc) RateLimitStore {
+	return &redisRateLimitStore{eval: eval}
+}
+
+func (s *redisRateLimitStore) Take(ctx context.Context, key string, rate float64, burst int) (time.Duration, error) {
+	result, err := s.eval(ctx, redisTokenBucketScript, []string{key}, strconv.FormatFloat(rate, 'f', -1, 64), burst)
+	if err != nil {
+		return 0, err
+	}
+
+	value, ok := result.(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected rate limit script result %T", result)
+	}
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected rate limit script result %q", value)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,218 @@
+package gozaya
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Redactor removes secrets from the text the client puts in errors, logs and dumps,
+// such as response bodies echoing the submitted form data.
+type Redactor interface {
+	Redact(text string) string
+}
+
+// RedactorFunc adapts a function to the Redactor interface
+type RedactorFunc func(text string) string
+
+// Redact calls f(text)
+func (f RedactorFunc) Redact(text string) string {
+	return f(text)
+}
+
+// DefaultSensitiveFields are the fields masked by DefaultRedactor
+var DefaultSensitiveFields = []string{
+	"password", "privacy_password", "token", "access_token", "refresh_token",
+	"api_key", "apikey", "secret", "authorization",
+}
+
+// DefaultRedactor masks bearer tokens and the values of DefaultSensitiveFields in JSON,
+// form-encoded and query string text
+var DefaultRedactor = NewFieldRedactor(DefaultSensitiveFields...)
+
+type fieldRedactor struct {
+	json   *regexp.Regexp
+	form   *regexp.Regexp
+	bearer *regexp.Regexp
+}
+
+var bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+
+// NewFieldRedactor returns a redactor masking bearer tokens and the values of the given
+// fields, matched case-insensitively, in JSON, form-encoded and query string text
+func NewFieldRedactor(fields ...string) Redactor {
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		quoted[i] = regexp.QuoteMeta(field)
+	}
+	names := strings.Join(quoted, "|")
+
+	return &fieldRedactor{
+		json:   regexp.MustCompile(`(?i)("(?:` + names + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`),
+		form:   regexp.MustCompile(`(?i)(\b(?:` + names + `)=)[^&\s"']*`),
+		bearer: bearerPattern,
+	}
+}
+
+// Redact masks the sensitive values of text
+func (r *fieldRedactor) Redact(text string) string {
+	text = r.json.ReplaceAllString(text, `${1}"`+redacted+`"`)
+	text = r.form.ReplaceAllString(text, "${1}"+redacted)
+	return r.bearer.ReplaceAllString(text, "${1}"+redacted)
+}
+
+// WithRedactor sets the redactor applied to error messages, DefaultRedactor by default.
+// The credentials of the client are always masked, whatever the redactor.
+func WithRedactor(redactor Redactor) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.redactor = redactor })
+	}
+}
+
+// secretBoundary matches the characters around a masked secret, which may not be letters,
+// digits, underscores or hyphens, so that a short secret is masked where it is a whole value
+// rather than inside the words containing it.
+const secretBoundary = `[^A-Za-z0-9_-]`
+
+// maskSecret masks the occurrences of secret in text that are whole values, e.g. a header
+// value, a field of a body or a query parameter, whatever the length of secret.
+func maskSecret(text string, secret string) string {
+	if secret == "" || !strings.Contains(text, secret) {
+		return text
+	}
+	pattern := regexp.MustCompile(`(^|` + secretBoundary + `)` + regexp.QuoteMeta(secret) + `($|` + secretBoundary + `)`)
+	// twice, as the boundary between two adjacent occurrences is consumed by the first one
+	for range 2 {
+		text = pattern.ReplaceAllString(text, "${1}"+redacted+"${2}")
+	}
+	return text
+}
+
+// redact applies the redactor of the client to text, and masks the given secrets.
+func (g *GoZaya) redact(text string, secrets ...string) string {
+	for _, secret := range secrets {
+		text = maskSecret(text, secret)
+	}
+	redactor := g.tuning().redactor
+	if redactor == nil {
+		redactor = DefaultRedactor
+	}
+	return redactor.Redact(text)
+}
+
+// redactError redacts the message of an API error in place.
+func (g *GoZaya) redactError(err error, req *resty.Request) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		secrets := requestSecrets(g, req)
+		apiErr.Message = g.redact(apiErr.Message, secrets...)
+		apiErr.URL = g.redact(apiErr.URL, secrets...)
+		if apiErr.Header != nil {
+			apiErr.Header = g.redactHeader(apiErr.Header, secrets)
+		}
+		if apiErr.Body != nil {
+			apiErr.Body = []byte(g.redact(string(apiErr.Body), secrets...))
+		}
+		if apiErr.Err != nil {
+			apiErr.Err = g.redactCause(apiErr.Err, secrets)
+		}
+	}
+	return err
+}
+
+// sensitiveHeaders are the headers of the error responses whose values are always masked
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// redactHeader returns a copy of the header of an error response, masking the values of the
+// sensitive headers and of the API key header, and redacting the others.
+func (g *GoZaya) redactHeader(header http.Header, secrets []string) http.Header {
+	redactedHeader := make(http.Header, len(header))
+	for name, values := range header {
+		sensitive := slices.Contains(sensitiveHeaders, http.CanonicalHeaderKey(name)) ||
+			g.authMode == AuthAPIKeyHeader && strings.EqualFold(name, g.authKeyName)
+		redactedValues := make([]string, len(values))
+		for i, value := range values {
+			if sensitive {
+				redactedValues[i] = redacted
+			} else {
+				redactedValues[i] = g.redact(value, secrets...)
+			}
+		}
+		redactedHeader[name] = redactedValues
+	}
+	return redactedHeader
+}
+
+// redactCause redacts the cause of an API error, keeping the errors it wraps matchable,
+// including the errors joined with errors.Join.
+func (g *GoZaya) redactCause(err error, secrets []string) error {
+	if urlErr, ok := err.(*url.Error); ok {
+		return &url.Error{Op: urlErr.Op, URL: g.redact(urlErr.URL, secrets...), Err: g.redactCause(urlErr.Err, secrets)}
+	}
+	message := err.Error()
+	redactedMessage := g.redact(message, secrets...)
+	if redactedMessage == message {
+		return err
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		causes := joined.Unwrap()
+		redactedCauses := make([]error, len(causes))
+		for i, cause := range causes {
+			redactedCauses[i] = g.redactCause(cause, secrets)
+		}
+		return &redactedJoinError{message: redactedMessage, errs: redactedCauses}
+	}
+	cause := errors.Unwrap(err)
+	if cause != nil {
+		cause = g.redactCause(cause, secrets)
+	}
+	return &redactedError{message: redactedMessage, err: cause}
+}
+
+// redactedError replaces the message of an error holding secrets
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactedJoinError replaces the message of an error joining several errors holding secrets
+type redactedJoinError struct {
+	message string
+	errs    []error
+}
+
+func (e *redactedJoinError) Error() string {
+	return e.message
+}
+
+func (e *redactedJoinError) Unwrap() []error {
+	return e.errs
+}
+
+// requestSecrets returns the credentials sent with a request.
+func requestSecrets(g *GoZaya, req *resty.Request) []string {
+	if req == nil {
+		return nil
+	}
+	secrets := []string{req.Token}
+	switch g.authMode {
+	case AuthAPIKeyHeader:
+		secrets = append(secrets, req.Header.Get(g.authKeyName))
+	case AuthAPIKeyQuery:
+		secrets = append(secrets, req.QueryParam.Get(g.authKeyName))
+	}
+	return secrets
+}
@@ -0,0 +1,219 @@
+// Package redirector is a reference implementation of a redirect proxy in front of a Zaya
+// instance. It resolves the aliases of the requested paths with GetLinkByAlias, cached by the
+// link cache of the client, and redirects to the destinations with 302 Found:
+//
+//	client := gozaya.NewClient(basePath, gozaya.PresetInteractive(), gozaya.WithLinkCache(time.Minute, 10000))
+//	http.ListenAndServe(":8080", redirector.New(redirector.Config{Client: client, Token: token}))
+package redirector
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gozaya "github.com/erfandiakoo/go-zaya"
+)
+
+const (
+	// defaultMissRate is the rate of the lookups of the aliases missing from the link cache
+	// by default, per second.
+	defaultMissRate = 10
+	// defaultMissBurst is the burst of the lookups of the aliases missing from the link cache
+	// by default.
+	defaultMissBurst = 20
+)
+
+// Config configures a Redirector
+type Config struct {
+	// Client looks the aliases up. Configure it WithLinkCache, which caches the links and the
+	// unknown aliases; without it, every request looks its alias up.
+	Client *gozaya.GoZaya
+	// Token is used for the lookups, empty to use the token provider of Client
+	Token string
+	// DomainID restricts the lookups to a branded domain, all domains when 0
+	DomainID int
+	// MissLimiter bounds the rate of the lookups of the aliases missing from the link cache,
+	// each costing up to a few pages of links, so that requests for random aliases cannot
+	// flood the API. It defaults to 10 lookups per second with bursts of 20.
+	MissLimiter gozaya.RateLimiter
+}
+
+// Metrics are the counters of a Redirector
+type Metrics struct {
+	Requests  uint64
+	Redirects uint64
+	NotFound  uint64
+	Errors    uint64
+	// CacheHits counts the requests served from the link cache, NegativeHits those of unknown
+	// aliases
+	CacheHits    uint64
+	NegativeHits uint64
+	// Lookups counts the calls to the API, LookupTime is their cumulated duration
+	Lookups    uint64
+	LookupTime time.Duration
+}
+
+// lookup is a lookup in flight, shared by the concurrent requests of an alias
+type lookup struct {
+	done chan struct{}
+	link *gozaya.Link
+	err  error
+}
+
+// Redirector is an http.Handler redirecting the paths /<alias> to the destination of the links
+type Redirector struct {
+	config Config
+
+	mu       sync.Mutex
+	inFlight map[string]*lookup
+
+	requests, redirects, notFound, errors atomic.Uint64
+	cacheHits, negativeHits, lookups      atomic.Uint64
+	lookupTime                            atomic.Int64
+}
+
+// New returns a Redirector
+func New(config Config) *Redirector {
+	if config.MissLimiter == nil {
+		config.MissLimiter = gozaya.NewTokenBucket(defaultMissRate, defaultMissBurst)
+	}
+	return &Redirector{
+		config:   config,
+		inFlight: make(map[string]*lookup),
+	}
+}
+
+// ServeHTTP redirects to the destination of the link of the alias in the path. Links past
+// their expiration redirect to their expiration URL when they have one, and password protected
+// links to their short URL, where Zaya asks for the password. It responds 404 when the alias is
+// unknown or the link does not redirect, and 502 when the lookup failed or was not allowed by
+// the miss limiter before the request was done.
+func (r *Redirector) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.requests.Add(1)
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	alias := strings.Trim(req.URL.Path, "/")
+	if alias == "" || strings.Contains(alias, "/") {
+		r.notFound.Add(1)
+		http.NotFound(w, req)
+		return
+	}
+
+	link, err := r.Resolve(req.Context(), alias)
+	if err != nil {
+		r.errors.Add(1)
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+
+	destination := target(link)
+	if destination == "" {
+		r.notFound.Add(1)
+		http.NotFound(w, req)
+		return
+	}
+	r.redirects.Add(1)
+	w.Header().Set("Cache-Control", "private, max-age=0")
+	http.Redirect(w, req, destination, http.StatusFound)
+}
+
+// target returns the URL a link redirects to, empty when it does not redirect.
+func target(link *gozaya.Link) string {
+	if link == nil {
+		return ""
+	}
+	switch link.State() {
+	case gozaya.LinkStateActive:
+		if link.HasPassword {
+			return link.ShortURL
+		}
+		return link.LongURL
+	case gozaya.LinkStateExpired:
+		return link.ExpirationURL
+	default:
+		return ""
+	}
+}
+
+// Resolve returns the link of an alias, from the link cache of the client when possible. It
+// returns nil without error when the alias is unknown. Concurrent lookups of the same alias
+// share one API call, started once allowed by the miss limiter.
+func (r *Redirector) Resolve(ctx context.Context, alias string) (*gozaya.Link, error) {
+	if link, ok := r.config.Client.CachedLinkByAlias(ctx, r.config.Token, alias, r.config.DomainID); ok {
+		if link == nil {
+			r.negativeHits.Add(1)
+		} else {
+			r.cacheHits.Add(1)
+		}
+		return link, nil
+	}
+
+	r.mu.Lock()
+	current, ok := r.inFlight[alias]
+	r.mu.Unlock()
+	if !ok {
+		if err := r.config.MissLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		r.mu.Lock()
+		if current, ok = r.inFlight[alias]; !ok {
+			current = &lookup{done: make(chan struct{})}
+			r.inFlight[alias] = current
+			// the lookup outlives the request starting it, as the others may wait for it
+			go r.lookup(context.WithoutCancel(ctx), alias, current)
+		}
+		r.mu.Unlock()
+	}
+
+	select {
+	case <-current.done:
+		return current.link, current.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// lookup fetches the link of an alias, cached by the link cache of the client.
+func (r *Redirector) lookup(ctx context.Context, alias string, current *lookup) {
+	start := time.Now()
+	link, err := r.config.Client.GetLinkByAlias(ctx, r.config.Token, alias, r.config.DomainID)
+	r.lookups.Add(1)
+	r.lookupTime.Add(int64(time.Since(start)))
+
+	if gozaya.IsNotFound(err) {
+		link, err = nil, nil
+	}
+	current.link, current.err = link, err
+
+	r.mu.Lock()
+	delete(r.inFlight, alias)
+	r.mu.Unlock()
+	close(current.done)
+}
+
+// Invalidate removes an alias from the link cache of the client, e.g. after its link was
+// updated elsewhere
+func (r *Redirector) Invalidate(alias string) {
+	r.config.Client.InvalidateCachedAlias(alias)
+}
+
+// Metrics returns the counters of the redirector
+func (r *Redirector) Metrics() Metrics {
+	return Metrics{
+		Requests:     r.requests.Load(),
+		Redirects:    r.redirects.Load(),
+		NotFound:     r.notFound.Load(),
+		Errors:       r.errors.Load(),
+		CacheHits:    r.cacheHits.Load(),
+		NegativeHits: r.negativeHits.Load(),
+		Lookups:      r.lookups.Load(),
+		LookupTime:   time.Duration(r.lookupTime.Load()),
+	}
+}
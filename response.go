@@ -0,0 +1,333 @@
+package gozaya
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// requestIDHeaders are the response headers looked up, in order, for the server request ID
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "Cf-Ray"}
+
+// RateLimit holds the rate limit state reported by the API
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window, -1 when not reported.
+	Limit int
+	// Remaining is the number of requests left in the current window, -1 when not reported.
+	Remaining int
+	// Reset is when the current window ends, zero when not reported.
+	Reset time.Time
+}
+
+// ResponseMeta holds the metadata of an API response
+type ResponseMeta struct {
+	// Operation is the operation the request belongs to
+	Operation  Operation
+	Method     string
+	URL        string
+	StatusCode int
+	Header     http.Header
+	// RequestID is the request ID assigned by the server, if any.
+	RequestID string
+	RateLimit RateLimit
+	Duration  time.Duration
+	// RequestSize and ResponseSize are the sizes in bytes of the request and response bodies.
+	// RequestSize is -1 when unknown, e.g. for a streamed body.
+	RequestSize  int64
+	ResponseSize int64
+	// InstanceID is the ID of the client that received the response, see GoZaya.InstanceID
+	InstanceID string
+	// CorrelationID is the correlation ID sent with the request, see WithCorrelationID
+	CorrelationID string
+}
+
+type responseMetaContextKey struct{}
+
+// responseMetaCapture is a ResponseMeta recorded by a context, along with the one recorded by
+// its parent context, if any
+type responseMetaCapture struct {
+	meta   *ResponseMeta
+	parent *responseMetaCapture
+}
+
+// CaptureResponseMeta returns a context recording the metadata of the responses received by the
+// calls made with it. The returned ResponseMeta holds the metadata of the last response once
+// the call returns, including for failed calls that received a response. When ctx already
+// captures the metadata, it keeps recording them.
+func CaptureResponseMeta(ctx context.Context) (context.Context, *ResponseMeta) {
+	meta := &ResponseMeta{}
+	parent, _ := ctx.Value(responseMetaContextKey{}).(*responseMetaCapture)
+	return context.WithValue(ctx, responseMetaContextKey{}, &responseMetaCapture{meta: meta, parent: parent}), meta
+}
+
+// WithResponseHook registers a callback called with the metadata of every response received
+// by the client, e.g. to log request IDs and rate limits.
+func WithResponseHook(hook func(ctx context.Context, meta *ResponseMeta)) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.responseHook = hook })
+	}
+}
+
+// WithErrorHook registers a callback called with the error of every failed call to the API,
+// after retries and redaction, e.g. to report the failures to an error tracker. The errors
+// raised by the client itself before calling the API, such as policy violations, are not
+// reported. hook is called before the call returns and must not block.
+func WithErrorHook(hook func(ctx context.Context, err *APIError)) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.errorHook = hook })
+	}
+}
+
+// SizeBudget configures WithSizeBudget
+type SizeBudget struct {
+	// MaxResponseSize is the size in bytes of the response bodies from which OnExceeded is
+	// called. Zero applies no budget.
+	MaxResponseSize int64
+	// Operations restricts the budget to some operations, e.g. OperationListLinks.
+	// All the operations are budgeted when empty.
+	Operations []Operation
+	// OnExceeded is called with the metadata of the responses exceeding the budget. It is
+	// called before the call returns and must not block.
+	OnExceeded func(ctx context.Context, meta *ResponseMeta)
+}
+
+// WithSizeBudget alerts when response bodies exceed a size budget, e.g. to catch list queries
+// fetching pathologically large pages before they exhaust memory. The responses are still
+// returned; the sizes of every response are also reported to the response hook.
+func WithSizeBudget(budget SizeBudget) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.sizeBudget = &budget })
+	}
+}
+
+// exceeds reports whether a response exceeds the budget
+func (b *SizeBudget) exceeds(meta *ResponseMeta) bool {
+	if b == nil || b.MaxResponseSize <= 0 || b.OnExceeded == nil || meta.ResponseSize <= b.MaxResponseSize {
+		return false
+	}
+	return len(b.Operations) == 0 || slices.Contains(b.Operations, meta.Operation)
+}
+
+// CallMeta holds the outcome of a call to the API, retries included
+type CallMeta struct {
+	Operation Operation
+	Method    string
+	// StatusCode is the status of the last response, 0 when no response was received
+	StatusCode int
+	// Attempts is the number of times the request was sent, retries included
+	Attempts int
+	// Duration is the time taken by the call, waits between retries included
+	Duration time.Duration
+	// Err is the error of the call, nil when it succeeded
+	Err        error
+	InstanceID string
+	// RequestID is the request ID assigned by the server to the last response, if any
+	RequestID string
+	// CorrelationID is the correlation ID sent with the request, see WithCorrelationID
+	CorrelationID string
+}
+
+// callHook is a call hook, named when set with WithNamedCallHook
+type callHook struct {
+	name string
+	hook func(ctx context.Context, meta *CallMeta)
+}
+
+// WithCallHook adds a callback called with the outcome of every call to the API, once its
+// retries are done, e.g. to record metrics. Unlike the other hooks, the call hooks add up
+// rather than replace each other; use WithNamedCallHook for an option applied again with
+// Apply. hook is called before the call returns and must not block.
+func WithCallHook(hook func(ctx context.Context, meta *CallMeta)) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if hook != nil {
+			g.tune(func(t *tuning) { t.callHooks = append(slices.Clip(t.callHooks), callHook{hook: hook}) })
+		}
+	}
+}
+
+// WithNamedCallHook sets the call hook named name, like WithCallHook, replacing the hook set
+// before under the same name, so that applying the option again, e.g. from a configuration
+// watcher calling Apply, does not add it twice. A nil hook removes the hook named name. An
+// empty name adds an unnamed hook, like WithCallHook.
+func WithNamedCallHook(name string, hook func(ctx context.Context, meta *CallMeta)) func(*GoZaya) {
+	if name == "" {
+		return WithCallHook(hook)
+	}
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) {
+			hooks := slices.DeleteFunc(slices.Clone(t.callHooks), func(h callHook) bool { return h.name == name })
+			if hook != nil {
+				hooks = append(hooks, callHook{name: name, hook: hook})
+			}
+			t.callHooks = hooks
+		})
+	}
+}
+
+// reportCall passes the outcome of a call to the logger, the call hooks and the events.
+func (g *GoZaya) reportCall(ctx context.Context, tuning *tuning, method string, start time.Time, attempts int, resp *resty.Response, err error) {
+	if len(tuning.callHooks) == 0 && tuning.logger == nil && tuning.events == nil {
+		return
+	}
+	meta := &CallMeta{
+		Operation:     OperationFromContext(ctx),
+		Method:        method,
+		Attempts:      attempts,
+		Duration:      time.Since(start),
+		Err:           err,
+		InstanceID:    g.instanceID,
+		CorrelationID: CorrelationIDFromContext(ctx),
+	}
+	if resp != nil && resp.RawResponse != nil {
+		meta.StatusCode = resp.StatusCode()
+		meta.RequestID = requestID(resp.Header())
+	}
+	g.logCall(ctx, tuning, meta)
+	for _, h := range tuning.callHooks {
+		h.hook(ctx, meta)
+	}
+	if tuning.events != nil {
+		if err != nil {
+			tuning.events.OnFailure(ctx, meta)
+		} else {
+			tuning.events.OnSuccess(ctx, meta)
+		}
+	}
+}
+
+// Reported reports whether the API reported any of the rate limit headers
+func (l RateLimit) Reported() bool {
+	return l.Limit >= 0 || l.Remaining >= 0 || !l.Reset.IsZero()
+}
+
+// WithRateLimitHook registers a callback called with the rate limit reported by every
+// response carrying the X-RateLimit headers, e.g. to alarm before the quota is exhausted.
+// hook is called before the call returns and must not block.
+func WithRateLimitHook(hook func(ctx context.Context, limit RateLimit)) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.rateLimitHook = hook })
+	}
+}
+
+// LastRateLimit returns the rate limit reported by the last response carrying the
+// X-RateLimit headers, and false when no response reported it yet. Clients derived with With
+// track their own rate limit, as they usually call the API with other tokens.
+func (g *GoZaya) LastRateLimit() (RateLimit, bool) {
+	if g.lastRateLimit == nil {
+		return RateLimit{Limit: -1, Remaining: -1}, false
+	}
+	limit := g.lastRateLimit.Load()
+	if limit == nil {
+		return RateLimit{Limit: -1, Remaining: -1}, false
+	}
+	return *limit, true
+}
+
+// reportRateLimit records the rate limit reported by a response and passes it to the hook.
+func (g *GoZaya) reportRateLimit(ctx context.Context, tuning *tuning, limit RateLimit) {
+	if !limit.Reported() {
+		return
+	}
+	if g.lastRateLimit != nil {
+		g.lastRateLimit.Store(&limit)
+	}
+	if tuning.rateLimitHook != nil {
+		tuning.rateLimitHook(ctx, limit)
+	}
+}
+
+// reportResponse passes the metadata of a response to the capturing context, the response
+// hook and the size budget.
+func (g *GoZaya) reportResponse(ctx context.Context, resp *resty.Response) {
+	tuning := g.tuning()
+	g.reportRateLimit(ctx, tuning, parseRateLimit(resp.Header(), time.Now()))
+
+	captured, _ := ctx.Value(responseMetaContextKey{}).(*responseMetaCapture)
+	if captured == nil && tuning.responseHook == nil && tuning.sizeBudget == nil {
+		return
+	}
+
+	meta := newResponseMeta(resp)
+	meta.Operation = OperationFromContext(ctx)
+	meta.InstanceID = g.instanceID
+	meta.CorrelationID = CorrelationIDFromContext(ctx)
+	for ; captured != nil; captured = captured.parent {
+		*captured.meta = *meta
+	}
+	if tuning.responseHook != nil {
+		tuning.responseHook(ctx, meta)
+	}
+	if tuning.sizeBudget.exceeds(meta) {
+		tuning.sizeBudget.OnExceeded(ctx, meta)
+	}
+}
+
+// reportAttempt passes the outcome of an attempt sent at sentAt to the attempt hook, with the
+// metadata of its response, or of its request when it failed without a response.
+func (g *GoZaya) reportAttempt(ctx context.Context, tuning *tuning, method, endpoint string, sentAt time.Time, resp *resty.Response, err error) {
+	if tuning.attemptHook == nil {
+		return
+	}
+	meta := &ResponseMeta{Method: method, URL: endpoint, Duration: time.Since(sentAt), RequestSize: -1}
+	if resp != nil && resp.RawResponse != nil {
+		meta = newResponseMeta(resp)
+	}
+	meta.Operation = OperationFromContext(ctx)
+	meta.InstanceID = g.instanceID
+	meta.CorrelationID = CorrelationIDFromContext(ctx)
+	tuning.attemptHook(ctx, meta, err)
+}
+
+func newResponseMeta(resp *resty.Response) *ResponseMeta {
+	meta := &ResponseMeta{
+		StatusCode:   resp.StatusCode(),
+		Header:       resp.Header(),
+		RequestID:    requestID(resp.Header()),
+		RateLimit:    parseRateLimit(resp.Header(), time.Now()),
+		Duration:     resp.Time(),
+		RequestSize:  -1,
+		ResponseSize: resp.Size(),
+	}
+	if resp.Request != nil {
+		meta.Method = resp.Request.Method
+		meta.URL = resp.Request.URL
+		if raw := resp.Request.RawRequest; raw != nil && (raw.ContentLength > 0 || raw.Body == nil || raw.Body == http.NoBody) {
+			meta.RequestSize = max(raw.ContentLength, 0)
+		}
+	}
+	return meta
+}
+
+// requestID returns the request ID assigned by the server
+func requestID(header http.Header) string {
+	for _, name := range requestIDHeaders {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// parseRateLimit reads the X-RateLimit-* headers. X-RateLimit-Reset is accepted
+// both as a Unix timestamp and as a number of seconds.
+func parseRateLimit(header http.Header, now time.Time) RateLimit {
+	limit := RateLimit{Limit: -1, Remaining: -1}
+	if value, err := strconv.Atoi(header.Get("X-RateLimit-Limit")); err == nil {
+		limit.Limit = value
+	}
+	if value, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil {
+		limit.Remaining = value
+	}
+	if value, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if value > now.Unix()/2 {
+			limit.Reset = time.Unix(value, 0)
+		} else {
+			limit.Reset = now.Add(time.Duration(value) * time.Second)
+		}
+	}
+	return limit
+}
@@ -0,0 +1,222 @@
+package gozaya
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// retryPolicy configures the retries of transient failures
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+// WithRetry retries the requests failing transiently, on timeouts, reset or refused
+// connections, temporary DNS failures and 502, 503 and 504 responses, up to maxAttempts attempts in total. The wait before
+// the nth retry is drawn at random between 0 and min(maxDelay, baseDelay × 2ⁿ⁻¹) (full jitter),
+// and is cut short when the context of the call is done. Only idempotent requests are retried:
+// POST requests are retried when they carry an Idempotency-Key header. WithRetryMaxElapsed and
+// WithRetryBudget bound the retries further.
+func WithRetry(maxAttempts int, baseDelay, maxDelay time.Duration) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if maxAttempts <= 1 {
+			g.tune(func(t *tuning) { t.retry = nil })
+			return
+		}
+		if maxDelay < baseDelay {
+			maxDelay = baseDelay
+		}
+		policy := &retryPolicy{maxAttempts: maxAttempts, baseDelay: baseDelay, maxDelay: maxDelay}
+		g.tune(func(t *tuning) { t.retry = policy })
+	}
+}
+
+// next reports whether a failed attempt is retried, and how long to wait before retrying.
+func (p *retryPolicy) next(ctx context.Context, attempt int, req *resty.Request, resp *resty.Response, sent bool, err error) (time.Duration, bool) {
+	if p == nil || !sent || attempt >= p.maxAttempts || ctx.Err() != nil {
+		return 0, false
+	}
+	if !retryableMethod(req) || !transientFailure(resp, err) {
+		return 0, false
+	}
+	return p.delay(attempt), true
+}
+
+// delay returns the full jitter wait before the retry following the given attempt.
+func (p *retryPolicy) delay(attempt int) time.Duration {
+	ceiling := p.maxDelay
+	if shift := attempt - 1; shift < 32 {
+		if backoff := p.baseDelay << shift; backoff > 0 && backoff < ceiling {
+			ceiling = backoff
+		}
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+// retryableMethod reports whether a request can be sent again without side effects.
+func retryableMethod(req *resty.Request) bool {
+	switch req.Method {
+	case http.MethodPost, http.MethodPatch:
+		return req.Header.Get("Idempotency-Key") != ""
+	default:
+		return true
+	}
+}
+
+// transientFailure reports whether an attempt failed in a way that may not happen again.
+func transientFailure(resp *resty.Response, err error) bool {
+	if err != nil {
+		return transientError(err)
+	}
+	switch resp.StatusCode() {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// transientError reports whether a request failed without a response in a way that may not
+// happen again: on timeouts, reset or refused connections and temporary DNS failures. TLS and
+// certificate failures, unknown hosts and canceled calls are permanent.
+func transientError(err error) bool {
+	switch ParseAPIErrType(err) {
+	case APIErrTypeTimeout:
+		return true
+	case APIErrTypeNetwork:
+		return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+	case APIErrTypeDNS:
+		var dnsErr *net.DNSError
+		return errors.As(err, &dnsErr) && (dnsErr.IsTemporary || dnsErr.IsTimeout)
+	default:
+		return false
+	}
+}
+
+// rateLimitRetryPolicy configures the retries of rate limited requests
+type rateLimitRetryPolicy struct {
+	maxRetries int
+	maxWait    time.Duration
+}
+
+// defaultRateLimitWait is the wait after a 429 response telling neither Retry-After nor
+// X-RateLimit-Reset, doubled on every consecutive retry.
+const defaultRateLimitWait = time.Second
+
+// WithRateLimitRetry makes the client wait and retry the requests rejected with 429 Too Many
+// Requests, up to maxRetries times in a row. The wait is the one requested through Retry-After
+// or X-RateLimit-Reset; a rate limited request is not retried when the API asks to wait longer
+// than maxWait, in which case the *RateLimitError is returned to let the caller schedule the
+// retry. Rate limited requests are never processed, so all methods are retried.
+func WithRateLimitRetry(maxRetries int, maxWait time.Duration) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if maxRetries <= 0 {
+			g.tune(func(t *tuning) { t.rateLimitRetry = nil })
+			return
+		}
+		policy := &rateLimitRetryPolicy{maxRetries: maxRetries, maxWait: maxWait}
+		g.tune(func(t *tuning) { t.rateLimitRetry = policy })
+	}
+}
+
+// next reports whether a rate limited attempt is retried, and how long to wait before retrying.
+func (p *rateLimitRetryPolicy) next(ctx context.Context, retries int, resp *resty.Response, err error) (time.Duration, bool) {
+	if p == nil || err != nil || resp == nil || resp.StatusCode() != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if retries >= p.maxRetries || ctx.Err() != nil {
+		return 0, false
+	}
+
+	now := time.Now()
+	wait := parseRetryAfter(resp.Header(), now)
+	if reset := parseRateLimit(resp.Header(), now).Reset; wait <= 0 && reset.After(now) {
+		wait = reset.Sub(now)
+	}
+	if wait <= 0 {
+		wait = defaultRateLimitWait << retries
+	}
+	if p.maxWait > 0 && wait > p.maxWait {
+		return 0, false
+	}
+	return wait, true
+}
+
+// WithRetryMaxElapsed stops retrying a call once maxElapsed has passed since its first attempt,
+// counting the waits: a retry whose wait would end past the deadline is not made. It applies
+// to the retries of WithRetry and WithRateLimitRetry. Zero or less removes the limit.
+func WithRetryMaxElapsed(maxElapsed time.Duration) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.retryMaxElapsed = max(maxElapsed, 0) })
+	}
+}
+
+// RetryBudget limits the share of retries across the clients it is given to, so that the
+// retries cannot amplify an outage. It starts with maxTokens tokens; every transient failure
+// takes a token, every success gives back ratio tokens, and retries are only made while more
+// than half of the tokens are left. It is safe for concurrent use.
+type RetryBudget struct {
+	maxTokens float64
+	ratio     float64
+
+	mu     sync.Mutex
+	tokens float64
+}
+
+// NewRetryBudget returns a retry budget of maxTokens tokens, refilled by ratio tokens per
+// success. For instance, NewRetryBudget(10, 0.1) stops retrying after 5 failures in a row,
+// and allows retries again once failures are less than 10% of the attempts.
+func NewRetryBudget(maxTokens float64, ratio float64) *RetryBudget {
+	maxTokens = max(maxTokens, 1)
+	return &RetryBudget{maxTokens: maxTokens, ratio: max(ratio, 0), tokens: maxTokens}
+}
+
+// WithRetryBudget makes the retries of WithRetry draw from budget, which may be shared by all
+// the clients of the process.
+func WithRetryBudget(budget *RetryBudget) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.retryBudget = budget })
+	}
+}
+
+// record accounts for the outcome of an attempt, failed when transient is true.
+func (b *RetryBudget) record(transient bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if transient {
+		b.tokens = max(b.tokens-1, 0)
+	} else {
+		b.tokens = min(b.tokens+b.ratio, b.maxTokens)
+	}
+}
+
+// allows reports whether a retry may be made.
+func (b *RetryBudget) allows() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tokens > b.maxTokens/2
+}
+
+// withinElapsed reports whether a retry after wait ends before the elapsed time limit of a
+// call started at start.
+func (t *tuning) withinElapsed(start time.Time, wait time.Duration) bool {
+	return t.retryMaxElapsed <= 0 || time.Since(start)+wait <= t.retryMaxElapsed
+}
@@ -0,0 +1,61 @@
+package gozaya
+
+import (
+	"math/rand/v2"
+)
+
+// DomainWeight is the share of newly created links assigned to a branded domain
+type DomainWeight struct {
+	DomainID int
+	Weight   int
+}
+
+// domainRotation picks branded domains at random, proportionally to their weight
+type domainRotation struct {
+	domains []DomainWeight
+	total   int
+}
+
+// WithDomainRotation distributes the links created without an explicit domain across the
+// given branded domains by weight, e.g. 70/30. Links created with GenerateLinkRequest.Domain
+// set keep that domain. Domains with a non-positive weight are ignored.
+func WithDomainRotation(domains ...DomainWeight) func(*GoZaya) {
+	return func(g *GoZaya) {
+		rotation := &domainRotation{}
+		for _, domain := range domains {
+			if domain.Weight <= 0 {
+				continue
+			}
+			rotation.domains = append(rotation.domains, domain)
+			rotation.total += domain.Weight
+		}
+		if rotation.total == 0 {
+			g.domainRotation = nil
+			return
+		}
+		g.domainRotation = rotation
+	}
+}
+
+// pick returns a domain ID chosen by weight
+func (r *domainRotation) pick() int {
+	n := rand.IntN(r.total)
+	for _, domain := range r.domains {
+		if n < domain.Weight {
+			return domain.DomainID
+		}
+		n -= domain.Weight
+	}
+	return r.domains[len(r.domains)-1].DomainID
+}
+
+// rotateDomain returns the link with a rotated domain when it has no explicit domain.
+// The caller's request is never modified.
+func (g *GoZaya) rotateDomain(link *GenerateLinkRequest) *GenerateLinkRequest {
+	if g.domainRotation == nil || link == nil || link.Domain != nil {
+		return link
+	}
+	rotated := *link
+	rotated.Domain = IntP(g.domainRotation.pick())
+	return &rotated
+}
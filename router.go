@@ -0,0 +1,161 @@
+package gozaya
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ErrUnknownAccount is returned when a call is routed to an account the router does not know
+var ErrUnknownAccount = errors.New("unknown account")
+
+// Account is a Zaya account served by a Router
+type Account struct {
+	// Name identifies the account in routing decisions and metrics
+	Name string
+	// Credential is the token of the account
+	Credential *Credential
+	// BaseURL overrides the base URL of the client when set, e.g. for a self-hosted instance
+	BaseURL string
+	// RateLimiter bounds the request rate of the account when set
+	RateLimiter RateLimiter
+	// Options are applied to the client of the account after the settings above
+	Options []func(*GoZaya)
+}
+
+// AccountMetrics are the counters of the requests sent for an account, retries included
+type AccountMetrics struct {
+	Requests int64
+	// Errors counts the requests failing with an error response or without a response
+	Errors int64
+	// Duration is the cumulated duration of the requests
+	Duration time.Duration
+}
+
+// RouteFunc returns the name of the account serving a call
+type RouteFunc func(ctx context.Context) (string, error)
+
+// Router serves several accounts through one client, selecting the account of every call
+// with a routing function. The clients of the accounts are derived from the base client
+// with With and share its connection pool.
+type Router struct {
+	route    RouteFunc
+	accounts map[string]*routedAccount
+}
+
+type routedAccount struct {
+	client   *GoZaya
+	requests atomic.Int64
+	errors   atomic.Int64
+	duration atomic.Int64
+}
+
+type accountContextKey struct{}
+
+// WithAccount returns a context routing the calls made with it to the named account by
+// the routers using RouteByContext
+func WithAccount(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, accountContextKey{}, name)
+}
+
+// RouteByContext routes the calls to the account set on their context with WithAccount
+func RouteByContext(ctx context.Context) (string, error) {
+	name, ok := ctx.Value(accountContextKey{}).(string)
+	if !ok {
+		return "", fmt.Errorf("%w: no account in context", ErrUnknownAccount)
+	}
+	return name, nil
+}
+
+// NewRouter returns a router serving the accounts through clients derived from base.
+// route defaults to RouteByContext.
+func NewRouter(base *GoZaya, route RouteFunc, accounts ...Account) (*Router, error) {
+	if route == nil {
+		route = RouteByContext
+	}
+	r := &Router{route: route, accounts: make(map[string]*routedAccount, len(accounts))}
+
+	for _, account := range accounts {
+		if _, ok := r.accounts[account.Name]; ok {
+			return nil, fmt.Errorf("duplicate account %q", account.Name)
+		}
+		routed := &routedAccount{}
+
+		options := []func(*GoZaya){withAttemptHook(routed.record)}
+		if account.Credential != nil {
+			options = append(options, WithCredential(account.Credential))
+		}
+		if account.BaseURL != "" {
+			options = append(options, WithBaseURL(account.BaseURL))
+		}
+		if account.RateLimiter != nil {
+			options = append(options, WithRateLimiter(account.RateLimiter))
+		}
+		options = append(options, account.Options...)
+
+		routed.client = base.With(options...)
+		r.accounts[account.Name] = routed
+	}
+	return r, nil
+}
+
+// record counts an attempt of the account, failed when it got an error response or no
+// response at all, e.g. on a connection failure.
+func (a *routedAccount) record(ctx context.Context, meta *ResponseMeta, err error) {
+	a.requests.Add(1)
+	if err != nil || meta.StatusCode >= 400 {
+		a.errors.Add(1)
+	}
+	a.duration.Add(int64(meta.Duration))
+}
+
+// withAttemptHook adds a callback called with the outcome of every attempt sent, after the
+// attempt hook set before, if any.
+func withAttemptHook(hook func(ctx context.Context, meta *ResponseMeta, err error)) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) {
+			next := t.attemptHook
+			if next == nil {
+				t.attemptHook = hook
+				return
+			}
+			t.attemptHook = func(ctx context.Context, meta *ResponseMeta, err error) {
+				next(ctx, meta, err)
+				hook(ctx, meta, err)
+			}
+		})
+	}
+}
+
+// Client returns the client of the account serving the call
+func (r *Router) Client(ctx context.Context) (*GoZaya, error) {
+	name, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.Account(name)
+}
+
+// Account returns the client of the named account
+func (r *Router) Account(name string) (*GoZaya, error) {
+	account, ok := r.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownAccount, name)
+	}
+	return account.client, nil
+}
+
+// Metrics returns the request counters of the named account
+func (r *Router) Metrics(name string) (AccountMetrics, error) {
+	account, ok := r.accounts[name]
+	if !ok {
+		return AccountMetrics{}, fmt.Errorf("%w %q", ErrUnknownAccount, name)
+	}
+	return AccountMetrics{
+		Requests: account.requests.Load(),
+		Errors:   account.errors.Load(),
+		Duration: time.Duration(account.duration.Load()),
+	}, nil
+}
@@ -0,0 +1,216 @@
+package gozaya
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// WithToken sets the token used by the calls made without an explicit token,
+// such as the ones made through the Links, Domains, Spaces and Pixels services.
+func WithToken(token string) func(*GoZaya) {
+	return WithTokenProvider(StaticToken(token))
+}
+
+// LinksService calls the link endpoints with the default token of the client
+type LinksService struct {
+	client *GoZaya
+}
+
+// Create creates a link
+func (s *LinksService) Create(ctx context.Context, link *GenerateLinkRequest) (*Link, error) {
+	return s.client.CreateLink(ctx, "", link)
+}
+
+// CreateWithSharePayload creates a link and returns its share payload
+func (s *LinksService) CreateWithSharePayload(ctx context.Context, link *GenerateLinkRequest, options ShareOptions) (*Link, *SharePayload, error) {
+	return s.client.CreateLinkWithSharePayload(ctx, "", link, options)
+}
+
+// Get returns a link
+func (s *LinksService) Get(ctx context.Context, id string) (*Link, error) {
+	return s.client.GetLink(ctx, "", id)
+}
+
+// WarmCache fetches links into the link cache
+func (s *LinksService) WarmCache(ctx context.Context, ids []string) error {
+	return s.client.WarmCache(ctx, "", ids)
+}
+
+// Update updates a link
+func (s *LinksService) Update(ctx context.Context, id string, link *GenerateLinkRequest) (*Link, error) {
+	return s.client.UpdateLink(ctx, "", id, link)
+}
+
+// Remove removes a link
+func (s *LinksService) Remove(ctx context.Context, id string) (*RemoveLinkResponse, error) {
+	return s.client.RemoveLink(ctx, "", id)
+}
+
+// List returns a page of links
+func (s *LinksService) List(ctx context.Context, params GetLinksParams) ([]*Link, *Page, error) {
+	return s.client.ListLinks(ctx, "", params)
+}
+
+// ListByDomain returns a page of the links of a branded domain
+func (s *LinksService) ListByDomain(ctx context.Context, domainID int, params GetLinksParams) ([]*Link, *Page, error) {
+	return s.client.ListLinksByDomain(ctx, "", domainID, params)
+}
+
+// ListByPixel returns a page of the links firing a pixel
+func (s *LinksService) ListByPixel(ctx context.Context, pixelID int, params GetLinksParams) ([]*Link, *Page, error) {
+	return s.client.ListLinksByPixel(ctx, "", pixelID, params)
+}
+
+// ListAll returns all the links matching params
+func (s *LinksService) ListAll(ctx context.Context, params GetLinksParams, maxResults int) ([]*Link, error) {
+	return s.client.ListAllLinks(ctx, "", params, maxResults)
+}
+
+// Iterate returns an iterator over the links matching params
+func (s *LinksService) Iterate(ctx context.Context, params GetLinksParams) iter.Seq2[*Link, error] {
+	return s.client.IterateLinks(ctx, "", params)
+}
+
+// PollChanges polls the links for changes
+func (s *LinksService) PollChanges(ctx context.Context, since time.Time, interval time.Duration) *LinkChangeStream {
+	return s.client.PollChanges(ctx, "", since, interval)
+}
+
+// Enable enables a disabled link
+func (s *LinksService) Enable(ctx context.Context, link *Link) (*Link, error) {
+	return s.client.EnableLink(ctx, "", link)
+}
+
+// Disable disables an active link
+func (s *LinksService) Disable(ctx context.Context, link *Link) (*Link, error) {
+	return s.client.DisableLink(ctx, "", link)
+}
+
+// Archive disables an expired link
+func (s *LinksService) Archive(ctx context.Context, link *Link) (*Link, error) {
+	return s.client.ArchiveLink(ctx, "", link)
+}
+
+// Delete removes a link and marks it deleted
+func (s *LinksService) Delete(ctx context.Context, link *Link) (*RemoveLinkResponse, error) {
+	return s.client.DeleteLink(ctx, "", link)
+}
+
+// Star marks a link as a favorite
+func (s *LinksService) Star(ctx context.Context, id string) (*Link, error) {
+	return s.client.StarLink(ctx, "", id)
+}
+
+// Unstar removes a link from the favorites
+func (s *LinksService) Unstar(ctx context.Context, id string) (*Link, error) {
+	return s.client.UnstarLink(ctx, "", id)
+}
+
+// ListStarred returns a page of the favorite links
+func (s *LinksService) ListStarred(ctx context.Context, params GetLinksParams) ([]*Link, *Page, error) {
+	return s.client.ListStarredLinks(ctx, "", params)
+}
+
+// BulkCreate creates all the links in chunks
+func (s *LinksService) BulkCreate(ctx context.Context, links []*GenerateLinkRequest, options BulkOptions) ([]BulkResult, error) {
+	return s.client.BulkCreateLinks(ctx, "", links, options)
+}
+
+// GetByAlias returns the link with the given alias on a domain
+func (s *LinksService) GetByAlias(ctx context.Context, alias string, domainID int) (*Link, error) {
+	return s.client.GetLinkByAlias(ctx, "", alias, domainID)
+}
+
+// CheckAliases checks whether the aliases are free on a domain
+func (s *LinksService) CheckAliases(ctx context.Context, aliases []string, domainID int) ([]AliasAvailability, error) {
+	return s.client.CheckAliases(ctx, "", aliases, domainID)
+}
+
+// GetStats returns a page of the stats of a link
+func (s *LinksService) GetStats(ctx context.Context, id string, params LinkStatsParams) ([]*StatsPoint, *Page, error) {
+	return s.client.GetLinkStats(ctx, "", id, params)
+}
+
+// BackfillStats writes the daily clicks of the links over a period to sink
+func (s *LinksService) BackfillStats(ctx context.Context, options BackfillOptions, sink SnapshotSink) error {
+	return s.client.BackfillStats(ctx, "", options, sink)
+}
+
+// Export exports the links matching options.Params
+func (s *LinksService) Export(ctx context.Context, options ExportOptions, sink func(ctx context.Context, partition int, links []*Link) error) error {
+	return s.client.ExportLinks(ctx, "", options, sink)
+}
+
+// GetPublicStatsPage returns the stats page of a link
+func (s *LinksService) GetPublicStatsPage(ctx context.Context, id string) (*PublicStatsPage, error) {
+	return s.client.GetPublicStatsPage(ctx, "", id)
+}
+
+// EnablePublicStats makes the stats page of a link public
+func (s *LinksService) EnablePublicStats(ctx context.Context, id string) (*PublicStatsPage, error) {
+	return s.client.EnablePublicStats(ctx, "", id)
+}
+
+// ProtectPublicStats protects the stats page of a link with a password
+func (s *LinksService) ProtectPublicStats(ctx context.Context, id string, password string) (*PublicStatsPage, error) {
+	return s.client.ProtectPublicStats(ctx, "", id, password)
+}
+
+// DisablePublicStats makes the stats page of a link private
+func (s *LinksService) DisablePublicStats(ctx context.Context, id string) (*PublicStatsPage, error) {
+	return s.client.DisablePublicStats(ctx, "", id)
+}
+
+// DomainsService calls the domain endpoints with the default token of the client
+type DomainsService struct {
+	client *GoZaya
+}
+
+// List returns a page of branded domains
+func (s *DomainsService) List(ctx context.Context, params ListParams) ([]*Domain, *Page, error) {
+	return s.client.ListDomains(ctx, "", params)
+}
+
+// ListAll returns all the branded domains
+func (s *DomainsService) ListAll(ctx context.Context, params ListParams, maxResults int) ([]*Domain, error) {
+	return s.client.ListAllDomains(ctx, "", params, maxResults)
+}
+
+// SpacesService calls the space endpoints with the default token of the client
+type SpacesService struct {
+	client *GoZaya
+}
+
+// List returns a page of spaces
+func (s *SpacesService) List(ctx context.Context, params ListParams) ([]*Space, *Page, error) {
+	return s.client.ListSpaces(ctx, "", params)
+}
+
+// ListAll returns all the spaces
+func (s *SpacesService) ListAll(ctx context.Context, params ListParams, maxResults int) ([]*Space, error) {
+	return s.client.ListAllSpaces(ctx, "", params, maxResults)
+}
+
+// PixelsService calls the pixel endpoints with the default token of the client
+type PixelsService struct {
+	client *GoZaya
+}
+
+// List returns a page of pixels
+func (s *PixelsService) List(ctx context.Context, params ListParams) ([]*Pixel, *Page, error) {
+	return s.client.ListPixels(ctx, "", params)
+}
+
+// ListAll returns all the pixels
+func (s *PixelsService) ListAll(ctx context.Context, params ListParams, maxResults int) ([]*Pixel, error) {
+	return s.client.ListAllPixels(ctx, "", params, maxResults)
+}
+
+// bindServices points the services of the client at it
+func (g *GoZaya) bindServices() {
+	g.Links = &LinksService{client: g}
+	g.Domains = &DomainsService{client: g}
+	g.Spaces = &SpacesService{client: g}
+	g.Pixels = &PixelsService{client: g}
+}
@@ -0,0 +1,152 @@
+package gozaya
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ShadowReadConfig configures the shadow-read comparison mode.
+// A share of the GET requests is replayed against a secondary instance and
+// the responses are compared with the primary ones.
+type ShadowReadConfig struct {
+	// BasePath is the base path of the secondary instance.
+	BasePath string
+	// Percent is the share of GET requests, between 0 and 100, mirrored to the secondary instance.
+	Percent float64
+	// OnDiff is called when the secondary response differs from the primary one
+	// or when the secondary request fails.
+	OnDiff func(ctx context.Context, diff ShadowReadDiff)
+	// MaxInFlight bounds the shadow requests in flight, the reads mirrored beyond it being
+	// skipped. It defaults to 16.
+	MaxInFlight int
+}
+
+// defaultShadowMaxInFlight is the number of shadow requests in flight by default.
+const defaultShadowMaxInFlight = 16
+
+// shadowReads is a ShadowReadConfig with the slots of the shadow requests in flight
+type shadowReads struct {
+	ShadowReadConfig
+	slots chan struct{}
+}
+
+// ShadowReadDiff describes a mismatch between a primary and a shadow response. The bodies and
+// the error are redacted like the errors of the client.
+type ShadowReadDiff struct {
+	Path          string
+	QueryParams   map[string]string
+	PrimaryStatus int
+	PrimaryBody   []byte
+	ShadowStatus  int
+	ShadowBody    []byte
+	ShadowErr     error
+}
+
+// WithShadowReads enables the shadow-read comparison mode.
+func WithShadowReads(config ShadowReadConfig) func(*GoZaya) {
+	return func(g *GoZaya) {
+		config.BasePath = strings.TrimRight(config.BasePath, urlSeparator)
+		if config.MaxInFlight <= 0 {
+			config.MaxInFlight = defaultShadowMaxInFlight
+		}
+		g.shadow = &shadowReads{ShadowReadConfig: config, slots: make(chan struct{}, config.MaxInFlight)}
+	}
+}
+
+// shadowRead mirrors a successful GET request to the shadow instance in the
+// background and reports the differences through the configured callback. The shadow request
+// is bounded by the timeout of the call, signed and limited like the primary one.
+func (g *GoZaya) shadowRead(ctx context.Context, token string, path string, queryParams map[string]string, primary *resty.Response) {
+	if g.shadow == nil || g.shadow.OnDiff == nil || g.shadow.Percent <= 0 {
+		return
+	}
+	if rand.Float64()*100 >= g.shadow.Percent {
+		return
+	}
+
+	config := g.shadow
+	select {
+	case config.slots <- struct{}{}:
+	default:
+		return
+	}
+	primaryStatus := primary.StatusCode()
+	primaryBody := primary.Body()
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		defer func() { <-config.slots }()
+		diff := ShadowReadDiff{
+			Path:          path,
+			QueryParams:   queryParams,
+			PrimaryStatus: primaryStatus,
+		}
+
+		token, err := g.authorize(ctx, token)
+		if err != nil {
+			diff.PrimaryBody = []byte(g.redact(string(primaryBody)))
+			diff.ShadowErr = err
+			config.OnDiff(ctx, diff)
+			return
+		}
+
+		req := g.GetRequestWithBearerAuthNoCache(ctx, "").
+			SetQueryParams(queryParams)
+		g.applyAuth(req, token)
+		resp, err := g.sendShadow(ctx, req, joinURL(config.BasePath, path))
+
+		secrets := requestSecrets(g, req)
+		diff.PrimaryBody = []byte(g.redact(string(primaryBody), secrets...))
+		if resp != nil {
+			diff.ShadowStatus = resp.StatusCode()
+			diff.ShadowBody = []byte(g.redact(string(resp.Body()), secrets...))
+		}
+		if err := checkForError(resp, err, "failed to shadow read"); err != nil {
+			diff.ShadowErr = g.redactError(err, req)
+			config.OnDiff(ctx, diff)
+			return
+		}
+
+		if diff.ShadowStatus != diff.PrimaryStatus || !sameJSON(primaryBody, resp.Body()) {
+			config.OnDiff(ctx, diff)
+		}
+	}()
+}
+
+// sendShadow sends a shadow request, signed, within the concurrency limit of the client and
+// bounded by the timeout of the call.
+func (g *GoZaya) sendShadow(ctx context.Context, req *resty.Request, endpoint string) (*resty.Response, error) {
+	tuning := g.tuning()
+	ctx, cancel := attemptContext(ctx, tuning, req)
+	defer cancel()
+
+	if g.signer != nil {
+		req.Method = http.MethodGet
+		if err := g.sign(req, endpoint); err != nil {
+			return nil, err
+		}
+	}
+	release, err := tuning.concurrency.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return req.Get(endpoint)
+}
+
+// sameJSON reports whether two bodies hold the same JSON document,
+// falling back to a byte comparison when they are not valid JSON.
+func sameJSON(a, b []byte) bool {
+	var objA, objB interface{}
+	if json.Unmarshal(a, &objA) != nil || json.Unmarshal(b, &objB) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(objA, objB)
+}
@@ -0,0 +1,105 @@
+package gozaya
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DeepLinkScheme is the scheme of the deep links to the links, e.g. "zaya://zaya.io/alias"
+const DeepLinkScheme = "zaya"
+
+// QRCodeEncoder renders content as a PNG image of a QR code, e.g. the share.QRCode encoder of
+// the share package
+type QRCodeEncoder interface {
+	EncodeQRCode(content string) ([]byte, error)
+}
+
+// ShareOptions configures NewSharePayload
+type ShareOptions struct {
+	// QRCode renders the QR code of the short URL. The payload has no QR code when not set.
+	QRCode QRCodeEncoder
+}
+
+// SharePayload holds the artifacts needed to share a link on physical media
+type SharePayload struct {
+	ShortURL string
+	// DeepLink is the short URL with the DeepLinkScheme, opening the link in the apps
+	// registering the scheme
+	DeepLink string
+	// QRCode is a PNG image of a QR code encoding the short URL, rendered by the QRCode
+	// encoder of the ShareOptions
+	QRCode []byte
+	// NDEF is an NFC Forum URI record of the short URL, to write on NFC tags
+	NDEF []byte
+}
+
+// NewSharePayload returns the short URL, deep link, QR code and NFC record of a link
+func NewSharePayload(link *Link, options ShareOptions) (*SharePayload, error) {
+	if link == nil || link.ShortURL == "" {
+		return nil, errors.New("failed to build share payload: link has no short URL")
+	}
+	parsed, err := url.Parse(link.ShortURL)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("failed to build share payload: invalid short URL %q", link.ShortURL)
+	}
+
+	var png []byte
+	if options.QRCode != nil {
+		if png, err = options.QRCode.EncodeQRCode(link.ShortURL); err != nil {
+			return nil, fmt.Errorf("failed to build share payload: %w", err)
+		}
+	}
+
+	deepLink := *parsed
+	deepLink.Scheme = DeepLinkScheme
+	return &SharePayload{
+		ShortURL: link.ShortURL,
+		DeepLink: deepLink.String(),
+		QRCode:   png,
+		NDEF:     ndefURIRecord(link.ShortURL),
+	}, nil
+}
+
+// CreateLinkWithSharePayload creates a link and returns it along with its share payload. When
+// the payload cannot be built, the created link is returned with the error.
+func (g *GoZaya) CreateLinkWithSharePayload(ctx context.Context, token string, link *GenerateLinkRequest, options ShareOptions) (*Link, *SharePayload, error) {
+	created, err := g.CreateLink(ctx, token, link)
+	if err != nil {
+		return nil, nil, err
+	}
+	payload, err := NewSharePayload(created, options)
+	return created, payload, err
+}
+
+// ndefURIPrefixes are the URI prefixes abbreviated by the NFC Forum URI record type, in the
+// order they are tried; the index is the identifier code.
+var ndefURIPrefixes = []string{1: "http://www.", 2: "https://www.", 3: "http://", 4: "https://"}
+
+// ndefURIRecord encodes uri as a single short NDEF record of the well-known type "U".
+func ndefURIRecord(uri string) []byte {
+	var code byte
+	for i, prefix := range ndefURIPrefixes {
+		if prefix != "" && strings.HasPrefix(uri, prefix) {
+			code = byte(i)
+			uri = strings.TrimPrefix(uri, prefix)
+			break
+		}
+	}
+
+	payload := append([]byte{code}, uri...)
+	// message begin, message end, well-known type, short record when the payload allows it
+	header := byte(0xC1)
+	record := []byte{header, 1}
+	if len(payload) < 256 {
+		record[0] |= 0x10
+		record = append(record, byte(len(payload)))
+	} else {
+		n := len(payload)
+		record = append(record, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	record = append(record, 'U')
+	return append(record, payload...)
+}
@@ -0,0 +1,31 @@
+// Package share renders the QR codes of the share payloads of the Zaya client.
+package share
+
+import (
+	gozaya "github.com/erfandiakoo/go-zaya"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const defaultSize = 256
+
+var _ gozaya.QRCodeEncoder = QRCode{}
+
+// QRCode renders QR codes as PNG images, e.g. for gozaya.ShareOptions:
+//
+//	payload, err := gozaya.NewSharePayload(link, gozaya.ShareOptions{QRCode: share.QRCode{Size: 512}})
+type QRCode struct {
+	// Size is the width and height in pixels of the QR code. It defaults to 256.
+	Size int
+	// Recovery is the error correction level of the QR code, qrcode.Low when not set. Higher
+	// levels survive damaged prints at the cost of a denser code.
+	Recovery qrcode.RecoveryLevel
+}
+
+// EncodeQRCode returns a PNG image of a QR code encoding content
+func (q QRCode) EncodeQRCode(content string) ([]byte, error) {
+	size := q.Size
+	if size <= 0 {
+		size = defaultSize
+	}
+	return qrcode.Encode(content, q.Recovery, size)
+}
@@ -0,0 +1,144 @@
+package gozaya
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RequestSigner signs the outgoing requests, e.g. for a self-hosted deployment behind a
+// signing gateway.
+type RequestSigner interface {
+	// Sign adds the signature of a request to header. body is the exact payload sent,
+	// empty for requests without a body.
+	Sign(method string, url *url.URL, header http.Header, body []byte) error
+}
+
+// WithRequestSigner signs every request with signer
+func WithRequestSigner(signer RequestSigner) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.signer = signer
+	}
+}
+
+const (
+	// DefaultSignatureHeader is the header of the signature set by HMACSigner
+	DefaultSignatureHeader = "X-Signature"
+	// DefaultTimestampHeader is the header of the signing timestamp set by HMACSigner
+	DefaultTimestampHeader = "X-Signature-Timestamp"
+)
+
+// HMACSigner signs requests with an HMAC of the timestamp and the body. The signature is
+// the hex-encoded HMAC of "<timestamp>.<body>", where the timestamp is the Unix time in
+// seconds sent in the timestamp header.
+type HMACSigner struct {
+	Secret []byte
+	// Hash is the hash function of the HMAC. It defaults to SHA-256.
+	Hash func() hash.Hash
+	// SignatureHeader defaults to DefaultSignatureHeader.
+	SignatureHeader string
+	// TimestampHeader defaults to DefaultTimestampHeader.
+	TimestampHeader string
+	// Now returns the signing time. It defaults to time.Now.
+	Now func() time.Time
+}
+
+// NewHMACSigner returns an HMAC-SHA256 signer using secret
+func NewHMACSigner(secret []byte) *HMACSigner {
+	return &HMACSigner{Secret: secret}
+}
+
+// Sign sets the timestamp and signature headers of a request
+func (s *HMACSigner) Sign(method string, url *url.URL, header http.Header, body []byte) error {
+	newHash := s.Hash
+	if newHash == nil {
+		newHash = sha256.New
+	}
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	timestamp := strconv.FormatInt(now().Unix(), 10)
+
+	mac := hmac.New(newHash, s.Secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	header.Set(headerOrDefault(s.TimestampHeader, DefaultTimestampHeader), timestamp)
+	header.Set(headerOrDefault(s.SignatureHeader, DefaultSignatureHeader), hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
+
+func headerOrDefault(header, fallback string) string {
+	if header == "" {
+		return fallback
+	}
+	return header
+}
+
+// sign signs a request about to be sent to url. The body of the request is first
+// serialized, so that the signature covers the exact bytes sent.
+func (g *GoZaya) sign(req *resty.Request, rawURL string) error {
+	body, err := serializeBody(req)
+	if err != nil {
+		return fmt.Errorf("failed to serialize body for signing: %w", err)
+	}
+
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse url for signing: %w", err)
+	}
+	query := target.Query()
+	for key, values := range req.QueryParam {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+	target.RawQuery = query.Encode()
+
+	return g.signer.Sign(req.Method, target, req.Header, body)
+}
+
+// serializeBody replaces the form data or body of a request with its encoded bytes.
+func serializeBody(req *resty.Request) ([]byte, error) {
+	var body []byte
+	switch value := req.Body.(type) {
+	case nil:
+		if len(req.FormData) == 0 {
+			return nil, nil
+		}
+		body = []byte(req.FormData.Encode())
+		req.FormData = url.Values{}
+		req.SetHeader("Content-Type", "application/x-www-form-urlencoded")
+	case []byte:
+		body = value
+	case string:
+		body = []byte(value)
+	case io.Reader:
+		read, err := io.ReadAll(value)
+		if err != nil {
+			return nil, err
+		}
+		body = read
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		body = encoded
+	}
+	req.SetBody(bytes.Clone(body))
+	return body, nil
+}
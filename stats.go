@@ -0,0 +1,239 @@
+package gozaya
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// statsDateLayout is the layout of the dates of the stats endpoint
+const statsDateLayout = "2006-01-02"
+
+// Names of the stats of a link, for LinkStatsParams.Name
+const (
+	StatsClicks    = "clicks"
+	StatsReferrers = "referrers"
+	StatsCountries = "countries"
+	StatsBrowsers  = "browsers"
+	StatsPlatforms = "platforms"
+)
+
+// LinkStatsParams represents the parameters of the stats of a link
+type LinkStatsParams struct {
+	// Name is the kind of stats, e.g. StatsClicks for the clicks per day
+	Name *string `json:"name,omitempty"`
+	// From and To bound the period of the stats, as YYYY-MM-DD dates
+	From    *string `json:"from,omitempty"`
+	To      *string `json:"to,omitempty"`
+	Page    *int    `json:"page,string,omitempty"`
+	PerPage *int    `json:"per_page,string,omitempty"`
+}
+
+// StatsPoint is a value of the stats of a link with its count, e.g. a day with its clicks
+type StatsPoint struct {
+	Value string
+	Count int64
+}
+
+// statsPointJSON is the wire representation of a stats point, named after its kind of stats.
+type statsPointJSON struct {
+	Value string  `json:"value"`
+	Date  string  `json:"date"`
+	Name  string  `json:"name"`
+	Count flexInt `json:"count"`
+}
+
+// UnmarshalJSON decodes a stats point
+func (p *StatsPoint) UnmarshalJSON(data []byte) error {
+	var point statsPointJSON
+	if err := json.Unmarshal(data, &point); err != nil {
+		return err
+	}
+	p.Count = int64(point.Count)
+	switch {
+	case point.Value != "":
+		p.Value = point.Value
+	case point.Date != "":
+		p.Value = point.Date
+	default:
+		p.Value = point.Name
+	}
+	return nil
+}
+
+// GetLinkStats returns a page of the stats of the link with the given ID
+func (g *GoZaya) GetLinkStats(ctx context.Context, token string, id string, params LinkStatsParams) ([]*StatsPoint, *Page, error) {
+	path, err := endpointPath("StatsEndpoint", g.Config.StatsEndpoint, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get link stats: %w", err)
+	}
+	return listPage[*StatsPoint](withOperation(ctx, OperationGetLinkStats), g, token, "StatsEndpoint", path, params, "get link stats")
+}
+
+// GetDailyClicks returns the clicks per day of a link between two days, included, with a
+// count for every day of the period
+func (g *GoZaya) GetDailyClicks(ctx context.Context, token string, id string, from, to time.Time) ([]StatsSnapshot, error) {
+	params := LinkStatsParams{
+		Name:    StringP(StatsClicks),
+		From:    StringP(from.Format(statsDateLayout)),
+		To:      StringP(to.Format(statsDateLayout)),
+		PerPage: IntP(listAllPageSize),
+	}
+	points, err := listAll(ctx, 0, func(page int) ([]*StatsPoint, *Page, error) {
+		params.Page = IntP(page)
+		return g.GetLinkStats(ctx, token, id, params)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clicks := make(map[string]int64, len(points))
+	for _, point := range points {
+		clicks[point.Value] += point.Count
+	}
+	linkID, _ := strconv.ParseInt(id, 10, 64)
+	var snapshots []StatsSnapshot
+	for day := truncateDay(from); !day.After(truncateDay(to)); day = day.AddDate(0, 0, 1) {
+		snapshots = append(snapshots, StatsSnapshot{LinkID: linkID, Date: day, Clicks: clicks[day.Format(statsDateLayout)]})
+	}
+	return snapshots, nil
+}
+
+// truncateDay returns the start of the day of t, in UTC.
+func truncateDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// StatsSnapshot is the number of clicks of a link on a day
+type StatsSnapshot struct {
+	LinkID int64
+	Alias  string
+	// Date is the start of the day, in UTC
+	Date   time.Time
+	Clicks int64
+}
+
+// SnapshotSink receives the snapshots of a backfill
+type SnapshotSink interface {
+	WriteSnapshots(ctx context.Context, snapshots []StatsSnapshot) error
+}
+
+// SnapshotSinkFunc adapts a function to the SnapshotSink interface
+type SnapshotSinkFunc func(ctx context.Context, snapshots []StatsSnapshot) error
+
+// WriteSnapshots calls f(ctx, snapshots)
+func (f SnapshotSinkFunc) WriteSnapshots(ctx context.Context, snapshots []StatsSnapshot) error {
+	return f(ctx, snapshots)
+}
+
+const (
+	// backfillDefaultBatchSize is the number of links of a backfill batch by default.
+	backfillDefaultBatchSize = 20
+	// backfillDefaultConcurrency is the number of links of a batch fetched concurrently by default.
+	backfillDefaultConcurrency = 4
+)
+
+// BackfillOptions configures BackfillStats
+type BackfillOptions struct {
+	// From and To are the first and last days of the backfill
+	From, To time.Time
+	// Params filters the links of the backfill. Page and PerPage are managed by the backfill.
+	Params GetLinksParams
+	// BatchSize is the number of links whose snapshots are written at once. It defaults to 20.
+	BatchSize int
+	// Concurrency is the number of links of a batch fetched concurrently. It defaults to 4.
+	Concurrency int
+}
+
+// BackfillStats writes the daily clicks of the links matching options.Params, for every day
+// between options.From and options.To, to sink. The links are processed in batches of
+// options.BatchSize, and each batch is written at once, in link order. Rate limited requests
+// are retried after the delay requested by the API, so a backfill paces itself to the rate
+// limit; combine with WithRateLimiter to stay below it. The backfill stops at the first error.
+func (g *GoZaya) BackfillStats(ctx context.Context, token string, options BackfillOptions, sink SnapshotSink) error {
+	if options.To.Before(options.From) {
+		return fmt.Errorf("failed to backfill stats: period ends on %s before it starts on %s", options.To.Format(statsDateLayout), options.From.Format(statsDateLayout))
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = backfillDefaultBatchSize
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = backfillDefaultConcurrency
+	}
+
+	params := options.Params
+	params.PerPage = IntP(listAllPageSize)
+	batch := make([]*Link, 0, options.BatchSize)
+	for link, err := range g.IterateLinks(ctx, token, params) {
+		if err != nil {
+			return err
+		}
+		batch = append(batch, link)
+		if len(batch) == options.BatchSize {
+			if err := g.backfillBatch(ctx, token, options, batch, sink); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return g.backfillBatch(ctx, token, options, batch, sink)
+}
+
+// backfillBatch fetches the daily clicks of a batch of links and writes them to sink.
+func (g *GoZaya) backfillBatch(ctx context.Context, token string, options BackfillOptions, links []*Link, sink SnapshotSink) error {
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	snapshots := make([][]StatsSnapshot, len(links))
+	errs := make([]error, len(links))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(options.Concurrency, len(links)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				link := links[index]
+				snapshots[index], errs[index] = g.GetDailyClicks(fetchCtx, token, strconv.FormatInt(link.ID, 10), options.From, options.To)
+				if errs[index] != nil {
+					// the other fetches of the batch are wasted
+					cancel()
+					continue
+				}
+				for day := range snapshots[index] {
+					snapshots[index][day].Alias = link.Alias
+				}
+			}
+		}()
+	}
+	for index := range links {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	// report the failure that stopped the batch rather than the cancellations it caused
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var batch []StatsSnapshot
+	for _, linkSnapshots := range snapshots {
+		batch = append(batch, linkSnapshots...)
+	}
+	return sink.WriteSnapshots(ctx, batch)
+}
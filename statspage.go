@@ -0,0 +1,97 @@
+package gozaya
+
+import (
+	"context"
+	"strconv"
+)
+
+// StatsPrivacy is the visibility of the stats page of a link
+type StatsPrivacy int
+
+const (
+	// StatsPublic makes the stats page readable by anyone with its URL.
+	StatsPublic StatsPrivacy = 0
+	// StatsPrivate restricts the stats page to the owner of the link.
+	StatsPrivate StatsPrivacy = 1
+	// StatsPasswordProtected makes the stats page readable with a password.
+	StatsPasswordProtected StatsPrivacy = 2
+)
+
+// PublicStatsPage describes the shareable stats page of a link
+type PublicStatsPage struct {
+	LinkID  int64
+	URL     string
+	Privacy StatsPrivacy
+	// Password is the password of a password protected page. The API never returns it,
+	// so it is only set by ProtectPublicStats.
+	Password string
+}
+
+// Shared reports whether the page is readable by people other than the owner of the link
+func (p *PublicStatsPage) Shared() bool {
+	return p.Privacy == StatsPublic || p.Privacy == StatsPasswordProtected
+}
+
+// PublicStatsURL returns the URL of the stats page of a link, empty when the
+// PublicStatsEndpoint of the client is invalid
+func (g *GoZaya) PublicStatsURL(linkID int64) string {
+	path, err := endpointPath("PublicStatsEndpoint", g.Config.PublicStatsEndpoint, strconv.FormatInt(linkID, 10))
+	if err != nil {
+		return ""
+	}
+	return joinURL(g.basePath, path)
+}
+
+// GetPublicStatsPage returns the stats page of a link
+func (g *GoZaya) GetPublicStatsPage(ctx context.Context, token string, id string) (*PublicStatsPage, error) {
+	link, err := g.GetLink(ctx, token, id)
+	if err != nil {
+		return nil, err
+	}
+	return g.publicStatsPage(link, "", "failed to get public stats page")
+}
+
+// EnablePublicStats makes the stats page of a link readable by anyone with its URL
+func (g *GoZaya) EnablePublicStats(ctx context.Context, token string, id string) (*PublicStatsPage, error) {
+	return g.setStatsPrivacy(ctx, token, id, StatsPublic, "")
+}
+
+// ProtectPublicStats makes the stats page of a link readable with the given password
+func (g *GoZaya) ProtectPublicStats(ctx context.Context, token string, id string, password string) (*PublicStatsPage, error) {
+	return g.setStatsPrivacy(ctx, token, id, StatsPasswordProtected, password)
+}
+
+// DisablePublicStats restricts the stats page of a link to its owner
+func (g *GoZaya) DisablePublicStats(ctx context.Context, token string, id string) (*PublicStatsPage, error) {
+	return g.setStatsPrivacy(ctx, token, id, StatsPrivate, "")
+}
+
+func (g *GoZaya) setStatsPrivacy(ctx context.Context, token string, id string, privacy StatsPrivacy, password string) (*PublicStatsPage, error) {
+	link, err := g.UpdateLink(ctx, token, id, &GenerateLinkRequest{
+		Privacy:         IntP(int(privacy)),
+		PrivacyPassword: password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	page, err := g.publicStatsPage(link, password, "failed to set stats privacy")
+	if err != nil {
+		return nil, err
+	}
+	page.Privacy = privacy
+	return page, nil
+}
+
+// publicStatsPage returns the stats page of a link returned by the API, which may send no
+// link in a successful response.
+func (g *GoZaya) publicStatsPage(link *Link, password string, errMessage string) (*PublicStatsPage, error) {
+	if link == nil {
+		return nil, &APIError{Message: errMessage + ": no link in response"}
+	}
+	return &PublicStatsPage{
+		LinkID:   link.ID,
+		URL:      g.PublicStatsURL(link.ID),
+		Privacy:  link.StatsPrivacy,
+		Password: password,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package gozaya
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// defaultTimeout bounds each attempt of a call unless changed with WithTimeout
+const defaultTimeout = 30 * time.Second
+
+// WithTimeout bounds each attempt of a call to timeout, e.g. 2s on interactive paths and
+// 60s for batch jobs. A retried call gets a new timeout per attempt; to bound a call as a
+// whole, retries included, use a context with a deadline, which is always honored. The
+// timeout defaults to 30s; zero or less disables it.
+func WithTimeout(timeout time.Duration) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tune(func(t *tuning) { t.timeout = max(timeout, 0) })
+	}
+}
+
+type timeoutContextKey struct{}
+
+// WithCallTimeout returns a context bounding each attempt of the calls made with it to
+// timeout, instead of the timeout of the client. Zero or less disables the timeout.
+func WithCallTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutContextKey{}, max(timeout, 0))
+}
+
+// attemptContext returns the context of an attempt, bounded by the timeout of the call, and
+// sets it on req.
+func attemptContext(ctx context.Context, tuning *tuning, req *resty.Request) (context.Context, context.CancelFunc) {
+	timeout, ok := ctx.Value(timeoutContextKey{}).(time.Duration)
+	if !ok {
+		timeout = tuning.timeout
+	}
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	req.SetContext(ctx)
+	return ctx, cancel
+}
@@ -0,0 +1,104 @@
+package gozaya
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"slices"
+)
+
+// tlsConfig returns the TLS configuration of the transport of the client to change it, or
+// records why it cannot be changed.
+func (g *GoZaya) tlsConfig(option string) (*tls.Config, bool) {
+	transport, err := g.transport()
+	if err != nil {
+		g.optionFailed(option, err)
+		return nil, false
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	return transport.TLSClientConfig, true
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the API, e.g. for a self-hosted
+// instance behind an internal PKI. config is copied, so later changes to it are ignored. It
+// replaces the certificates set by the options applied before it.
+func WithTLSConfig(config *tls.Config) func(*GoZaya) {
+	return func(g *GoZaya) {
+		transport, err := g.transport()
+		if err != nil {
+			g.optionFailed("WithTLSConfig", err)
+			return
+		}
+		transport.TLSClientConfig = config.Clone()
+	}
+}
+
+// WithCACertPEM trusts the PEM encoded CA certificates of pemCerts in addition to the system
+// CAs, e.g. the CA bundle of an internal PKI.
+func WithCACertPEM(pemCerts []byte) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.addRootCAs("WithCACertPEM", pemCerts)
+	}
+}
+
+// WithCACertFile trusts the PEM encoded CA certificates of the file at path in addition to
+// the system CAs. It fails, and then every call, when the file cannot be read.
+func WithCACertFile(path string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		pemCerts, err := os.ReadFile(path)
+		if err != nil {
+			g.optionFailed("WithCACertFile", err)
+			return
+		}
+		g.addRootCAs("WithCACertFile", pemCerts)
+	}
+}
+
+// addRootCAs adds the PEM encoded CA certificates to the trusted CAs of the client.
+func (g *GoZaya) addRootCAs(option string, pemCerts []byte) {
+	config, ok := g.tlsConfig(option)
+	if !ok {
+		return
+	}
+	pool := config.RootCAs
+	if pool == nil {
+		var err error
+		if pool, err = x509.SystemCertPool(); err != nil {
+			pool = x509.NewCertPool()
+		}
+	} else {
+		pool = pool.Clone()
+	}
+	if !pool.AppendCertsFromPEM(pemCerts) {
+		g.optionFailed(option, errors.New("no PEM encoded certificate found"))
+		return
+	}
+	config.RootCAs = pool
+}
+
+// WithClientCertificate authenticates the client to the server with cert, e.g. obtained with
+// tls.X509KeyPair, for instances requiring mutual TLS.
+func WithClientCertificate(cert tls.Certificate) func(*GoZaya) {
+	return func(g *GoZaya) {
+		if config, ok := g.tlsConfig("WithClientCertificate"); ok {
+			config.Certificates = append(slices.Clip(config.Certificates), cert)
+		}
+	}
+}
+
+// WithClientCertFiles authenticates the client to the server with the PEM encoded certificate
+// and private key of the files at certFile and keyFile, for instances requiring mutual TLS.
+// It fails, and then every call, when the files cannot be loaded.
+func WithClientCertFiles(certFile, keyFile string) func(*GoZaya) {
+	return func(g *GoZaya) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			g.optionFailed("WithClientCertFiles", err)
+			return
+		}
+		WithClientCertificate(cert)(g)
+	}
+}
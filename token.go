@@ -0,0 +1,212 @@
+package gozaya
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// TokenProvider provides the token of the calls made without an explicit token.
+// It is consulted for every such request.
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenProviderFunc adapts a function to the TokenProvider interface
+type TokenProviderFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx)
+func (f TokenProviderFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticToken returns a provider always returning the same token
+func StaticToken(token string) TokenProvider {
+	return StaticCredential(NewCredential(token))
+}
+
+// StaticCredential returns a provider always returning the secret of credential.
+// Destroying the credential revokes the token from the client.
+func StaticCredential(credential *Credential) TokenProvider {
+	return TokenProviderFunc(func(context.Context) (string, error) {
+		return credential.Reveal(), nil
+	})
+}
+
+// TokenFetcher obtains a new token along with its expiry time.
+// A zero expiry time means the token does not expire.
+type TokenFetcher func(ctx context.Context) (token string, expiresAt time.Time, err error)
+
+// RefreshingTokenProvider caches the token obtained from a TokenFetcher and fetches
+// a new one shortly before it expires. It is safe for concurrent use: a single fetch
+// is in flight at any time and the concurrent callers share its result.
+type RefreshingTokenProvider struct {
+	fetch  TokenFetcher
+	leeway time.Duration
+
+	mu sync.Mutex
+	// token is zeroed when it is replaced or invalidated
+	token     *Credential
+	expiresAt time.Time
+	// renewAt is when the token is renewed in the background, before it expires
+	renewAt time.Time
+	// refreshing is the fetch in flight, nil when there is none
+	refreshing *tokenRefresh
+}
+
+// tokenRefresh is a fetch shared by the callers waiting for a token.
+type tokenRefresh struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+const (
+	// defaultTokenLeeway is how long before expiry a token is refreshed by default.
+	defaultTokenLeeway = time.Minute
+	// tokenRenewRetryDelay is the wait before retrying a failed background renewal.
+	tokenRenewRetryDelay = 5 * time.Second
+)
+
+// NewRefreshingTokenProvider returns a provider caching the tokens returned by fetch.
+// Tokens are renewed in the background between leeway and 1.5 × leeway before they
+// expire, the jitter spreading the renewals of the replicas sharing an account.
+// leeway defaults to one minute when 0.
+func NewRefreshingTokenProvider(fetch TokenFetcher, leeway time.Duration) *RefreshingTokenProvider {
+	if leeway <= 0 {
+		leeway = defaultTokenLeeway
+	}
+	return &RefreshingTokenProvider{fetch: fetch, leeway: leeway}
+}
+
+// Token returns the cached token. A token past its renewal time is still returned while a
+// new one is fetched in the background; callers only wait when there is no valid token,
+// until the fetch completes or ctx is done.
+func (p *RefreshingTokenProvider) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	now := time.Now()
+	if !p.token.Empty() && (p.expiresAt.IsZero() || now.Before(p.expiresAt)) {
+		token := p.token.Reveal()
+		if !p.expiresAt.IsZero() && !now.Before(p.renewAt) {
+			p.refresh(ctx)
+		}
+		p.mu.Unlock()
+		return token, nil
+	}
+	refresh := p.refresh(ctx)
+	p.mu.Unlock()
+
+	select {
+	case <-refresh.done:
+		if refresh.err != nil {
+			return "", fmt.Errorf("failed to refresh token: %w", refresh.err)
+		}
+		return refresh.token, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// refresh starts a fetch unless one is in flight and returns the fetch in flight.
+// It must be called with p.mu held. The fetch is not canceled with ctx since other
+// callers may be waiting for it.
+func (p *RefreshingTokenProvider) refresh(ctx context.Context) *tokenRefresh {
+	if p.refreshing != nil {
+		return p.refreshing
+	}
+
+	refresh := &tokenRefresh{done: make(chan struct{})}
+	p.refreshing = refresh
+
+	go func() {
+		token, expiresAt, err := p.fetch(context.WithoutCancel(ctx))
+
+		p.mu.Lock()
+		if err == nil {
+			p.token.Destroy()
+			p.token = NewCredential(token)
+			p.expiresAt = expiresAt
+			p.renewAt = p.renewalTime(expiresAt)
+		} else if !p.renewAt.IsZero() {
+			p.renewAt = time.Now().Add(tokenRenewRetryDelay)
+		}
+		p.refreshing = nil
+		p.mu.Unlock()
+
+		refresh.token, refresh.err = token, err
+		close(refresh.done)
+	}()
+
+	return refresh
+}
+
+// renewalTime returns when a token expiring at expiresAt is renewed.
+func (p *RefreshingTokenProvider) renewalTime(expiresAt time.Time) time.Time {
+	if expiresAt.IsZero() {
+		return time.Time{}
+	}
+	jitter := time.Duration(rand.Int64N(int64(p.leeway/2) + 1))
+	return expiresAt.Add(-p.leeway - jitter)
+}
+
+// Invalidate drops the cached token, e.g. after the API rejected it
+func (p *RefreshingTokenProvider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.token.Destroy()
+	p.token = nil
+	p.expiresAt = time.Time{}
+	p.renewAt = time.Time{}
+}
+
+// tokenInvalidator is implemented by the token providers able to drop a rejected token
+type tokenInvalidator interface {
+	Invalidate()
+}
+
+// WithTokenProvider sets the provider of the token used by the calls made without an explicit token.
+func WithTokenProvider(provider TokenProvider) func(*GoZaya) {
+	return func(g *GoZaya) {
+		g.tokenProvider = provider
+	}
+}
+
+type callTokenContextKey struct{}
+
+// WithCallToken returns a context overriding the credentials of the client for the calls
+// made with it, e.g. to act on behalf of another account through a shared client.
+// A token passed explicitly to a call still takes precedence.
+func WithCallToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, callTokenContextKey{}, token)
+}
+
+// callToken returns the token set with WithCallToken, if any.
+func callToken(ctx context.Context) string {
+	token, _ := ctx.Value(callTokenContextKey{}).(string)
+	return token
+}
+
+// authorize resolves the token of a request: the explicit token, then the token of the
+// call context, then the token of the provider.
+func (g *GoZaya) authorize(ctx context.Context, token string) (string, error) {
+	if token != "" {
+		return token, nil
+	}
+	if token := callToken(ctx); token != "" {
+		return token, nil
+	}
+	if g.tokenProvider == nil {
+		return "", nil
+	}
+	token, err := g.tokenProvider.Token(ctx)
+	if err != nil {
+		return "", &APIError{
+			Message: fmt.Sprintf("failed to get token: %s", err),
+			Type:    ParseAPIErrType(err),
+		}
+	}
+	return token, nil
+}
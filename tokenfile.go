@@ -0,0 +1,93 @@
+package gozaya
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultTokenFileCheckInterval is how often FileTokenProvider checks the file by default.
+const defaultTokenFileCheckInterval = time.Second
+
+// FileTokenProvider reads the token from a file and reads it again when the file changes,
+// e.g. when a mounted Kubernetes secret is rotated. Surrounding whitespace is trimmed.
+type FileTokenProvider struct {
+	path          string
+	checkInterval time.Duration
+
+	mu        sync.Mutex
+	token     *Credential
+	modTime   time.Time
+	size      int64
+	checkedAt time.Time
+}
+
+// NewFileTokenProvider returns a provider reading the token from the file at path. The file
+// is checked for changes at most every checkInterval, every second when checkInterval is 0.
+func NewFileTokenProvider(path string, checkInterval time.Duration) *FileTokenProvider {
+	if checkInterval <= 0 {
+		checkInterval = defaultTokenFileCheckInterval
+	}
+	return &FileTokenProvider{path: path, checkInterval: checkInterval}
+}
+
+// Token returns the token of the file, reading the file again when it changed
+func (p *FileTokenProvider) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if !p.token.Empty() && now.Sub(p.checkedAt) < p.checkInterval {
+		return p.token.Reveal(), nil
+	}
+	p.checkedAt = now
+
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat token file: %w", err)
+	}
+	if !p.token.Empty() && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return p.token.Reveal(), nil
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	clear(data)
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", p.path)
+	}
+
+	p.token.Destroy()
+	p.token = NewCredential(token)
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	return token, nil
+}
+
+// Invalidate forces the file to be read again on the next call, e.g. after the API
+// rejected the token
+func (p *FileTokenProvider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.token.Destroy()
+	p.token = nil
+}
+
+// EnvToken returns a provider reading the token from the environment variable name on
+// every call, so that changes made with os.Setenv are picked up
+func EnvToken(name string) TokenProvider {
+	return TokenProviderFunc(func(context.Context) (string, error) {
+		token := strings.TrimSpace(os.Getenv(name))
+		if token == "" {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return token, nil
+	})
+}
@@ -0,0 +1,29 @@
+//go:build !gozaya_opentracing
+
+package gozaya
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// WithTracer returns ctx unchanged, as the trace of the calls is propagated with the global
+// OpenTelemetry propagator. The spans of an OpenTracing tracer are still propagated when the
+// tracer is bridged to OpenTelemetry with go.opentelemetry.io/otel/bridge/opentracing.
+//
+// Deprecated: propagate the trace with OpenTelemetry, or build with the gozaya_opentracing
+// tag, whose WithTracer propagates the OpenTracing spans of ctx with tracer. WithTracer will
+// be removed from the default build in the next major version.
+func WithTracer(ctx context.Context, tracer interface{}) context.Context {
+	return ctx
+}
+
+// injectTracingHeaders propagates the OpenTelemetry trace of ctx to the request with the
+// global propagator. Build with the gozaya_opentracing tag to propagate OpenTracing spans instead.
+func injectTracingHeaders(ctx context.Context, req *resty.Request) *resty.Request {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return req
+}
@@ -0,0 +1,42 @@
+//go:build gozaya_opentracing
+
+package gozaya
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/opentracing/opentracing-go"
+)
+
+type contextKey string
+
+var tracerContextKey = contextKey("tracer")
+
+// WithTracer generates a context that has a tracer attached.
+// It is only available when building with the gozaya_opentracing tag.
+func WithTracer(ctx context.Context, tracer opentracing.Tracer) context.Context {
+	return context.WithValue(ctx, tracerContextKey, tracer)
+}
+
+func injectTracingHeaders(ctx context.Context, req *resty.Request) *resty.Request {
+	// look for span in context, do nothing if span is not found
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return req
+	}
+
+	// look for tracer in context, use global tracer if not found
+	tracer, ok := ctx.Value(tracerContextKey).(opentracing.Tracer)
+	if !ok || tracer == nil {
+		tracer = opentracing.GlobalTracer()
+	}
+
+	// inject tracing header into request
+	err := tracer.Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header))
+	if err != nil {
+		return req
+	}
+
+	return req
+}
@@ -2,14 +2,11 @@ package gozaya
 
 import (
 	"context"
-
-	"github.com/opentracing/opentracing-go"
+	"net/http"
+	"strconv"
+	"time"
 )
 
-type contextKey string
-
-var tracerContextKey = contextKey("tracer")
-
 // StringP returns a pointer of a string variable
 func StringP(value string) *string {
 	return &value
@@ -122,11 +119,38 @@ func NilOrEmptySlice(value *[]string) bool {
 	return value == nil || len(*value) == 0
 }
 
-// WithTracer generates a context that has a tracer attached
-func WithTracer(ctx context.Context, tracer opentracing.Tracer) context.Context {
-	return context.WithValue(ctx, tracerContextKey, tracer)
-}
-
 func Ptr(s string) *string {
 	return &s
 }
+
+// parseRetryAfter returns the delay requested by a Retry-After header,
+// given either in seconds or as an HTTP date. It returns 0 when the header is missing or invalid.
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// sleepContext waits for d or until ctx is done, whichever happens first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,44 @@
+package gozaya
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// modulePath is the import path of this module
+const modulePath = "github.com/erfandiakoo/go-zaya"
+
+// Version is the version of the gozaya module linked into the binary, as recorded
+// in the build info. It is "devel" when the version cannot be determined,
+// e.g. when building from a local checkout.
+var Version = moduleVersion()
+
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+	version := ""
+	if info.Main.Path == modulePath {
+		version = info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != modulePath {
+			continue
+		}
+		version = dep.Version
+		if dep.Replace != nil && dep.Replace.Version != "" {
+			version = dep.Replace.Version
+		}
+	}
+	if version == "" || version == "(devel)" {
+		return "devel"
+	}
+	return version
+}
+
+// defaultUserAgent is the User-Agent sent with every request, e.g. "go-zaya/v1.4.0 (go 1.24.5)"
+func defaultUserAgent() string {
+	return "go-zaya/" + Version + " (go " + strings.TrimPrefix(runtime.Version(), "go") + ")"
+}
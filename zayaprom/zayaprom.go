@@ -0,0 +1,156 @@
+// Package zayaprom records Prometheus metrics of the calls made by the Zaya client.
+package zayaprom
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	gozaya "github.com/erfandiakoo/go-zaya"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "zaya_client"
+
+// options configures NewMetrics
+type options struct {
+	instanceLabel bool
+	buckets       []float64
+}
+
+// MetricsOption configures NewMetrics
+type MetricsOption func(*options)
+
+// WithInstanceLabel labels the metrics with the instance ID of the client, to tell apart the
+// clients of a process calling the API for different tenants.
+func WithInstanceLabel() MetricsOption {
+	return func(o *options) {
+		o.instanceLabel = true
+	}
+}
+
+// WithBuckets sets the buckets in seconds of the latency histogram, prometheus.DefBuckets by default.
+func WithBuckets(buckets []float64) MetricsOption {
+	return func(o *options) {
+		o.buckets = buckets
+	}
+}
+
+// hookName is the name of the call hook set by WithMetrics
+const hookName = "zayaprom"
+
+// Metrics are the collectors of the calls, shared by the clients recording with them
+type Metrics struct {
+	instanceLabel bool
+	requests      *prometheus.CounterVec
+	errors        *prometheus.CounterVec
+	retries       *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+}
+
+// NewMetrics registers the following metrics with reg, labeled by operation:
+//
+//   - zaya_client_requests_total, the calls made
+//   - zaya_client_errors_total, the failed calls, also labeled by status class: "4xx", "5xx",
+//     or "none" when no response was received
+//   - zaya_client_retries_total, the requests sent again by the retry policies
+//   - zaya_client_request_duration_seconds, the latency of the calls, retries included
+//
+// The metrics already registered with reg under the same names are reused, so that several
+// Metrics, and thus clients, may register on the same reg; their metrics then add up unless
+// labeled with WithInstanceLabel. It fails when reg holds other metrics of the same names.
+func NewMetrics(reg prometheus.Registerer, metricsOptions ...MetricsOption) (*Metrics, error) {
+	o := options{buckets: prometheus.DefBuckets}
+	for _, option := range metricsOptions {
+		option(&o)
+	}
+	labels := []string{"operation"}
+	if o.instanceLabel {
+		labels = append(labels, "instance_id")
+	}
+
+	m := &Metrics{instanceLabel: o.instanceLabel}
+	var err error
+	if m.requests, err = register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "Calls made to the Zaya API.",
+	}, labels)); err != nil {
+		return nil, err
+	}
+	if m.errors, err = register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "errors_total",
+		Help:      "Failed calls to the Zaya API, by status class.",
+	}, append(labels, "status_class"))); err != nil {
+		return nil, err
+	}
+	if m.retries, err = register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "retries_total",
+		Help:      "Requests to the Zaya API sent again by the retry policies.",
+	}, labels)); err != nil {
+		return nil, err
+	}
+	if m.duration, err = register(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the calls to the Zaya API, retries included.",
+		Buckets:   o.buckets,
+	}, labels)); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WithMetrics records the metrics of the calls of the client with m. It replaces the metrics
+// set before with WithMetrics, so that applying it again with Apply does not count the calls
+// twice.
+//
+//	metrics, err := zayaprom.NewMetrics(prometheus.DefaultRegisterer)
+//	...
+//	client := gozaya.NewClient(basePath, zayaprom.WithMetrics(metrics))
+func WithMetrics(m *Metrics) gozaya.Option {
+	if m == nil {
+		return gozaya.WithNamedCallHook(hookName, nil)
+	}
+	return gozaya.WithNamedCallHook(hookName, m.record)
+}
+
+// record records the outcome of a call
+func (m *Metrics) record(ctx context.Context, meta *gozaya.CallMeta) {
+	values := []string{string(meta.Operation)}
+	if m.instanceLabel {
+		values = append(values, meta.InstanceID)
+	}
+	m.requests.WithLabelValues(values...).Inc()
+	m.duration.WithLabelValues(values...).Observe(meta.Duration.Seconds())
+	if meta.Attempts > 1 {
+		m.retries.WithLabelValues(values...).Add(float64(meta.Attempts - 1))
+	}
+	if meta.Err != nil {
+		m.errors.WithLabelValues(append(values, statusClass(meta.StatusCode))...).Inc()
+	}
+}
+
+// register registers a collector, or returns the one already registered under its name.
+func register[C prometheus.Collector](reg prometheus.Registerer, collector C) (C, error) {
+	if err := reg.Register(collector); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if errors.As(err, &registered) {
+			if existing, ok := registered.ExistingCollector.(C); ok {
+				return existing, nil
+			}
+		}
+		return collector, err
+	}
+	return collector, nil
+}
+
+// statusClass returns the class of a status code, e.g. "4xx", "none" without a response.
+func statusClass(code int) string {
+	if code < 100 {
+		return "none"
+	}
+	return strconv.Itoa(code/100) + "xx"
+}